@@ -0,0 +1,335 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// コンテキストキー
+const (
+	ContextUserID      = "userID"
+	ContextUserEmail   = "userEmail"
+	ContextUserRole    = "userRole"
+	ContextAuthFailure = "authFailure"
+)
+
+// Reason 認証失敗の理由コード（401レスポンスの "code" に入る）
+type Reason string
+
+const (
+	ReasonMissingToken      Reason = "missing_token"
+	ReasonMalformedHeader   Reason = "malformed_authorization_header"
+	ReasonMalformedToken    Reason = "malformed_token"
+	ReasonInvalidSignature  Reason = "invalid_signature"
+	ReasonTokenExpired      Reason = "token_expired"
+	ReasonTokenNotValidYet  Reason = "token_not_valid_yet"
+	ReasonInvalidToken      Reason = "invalid_token"
+	ReasonInvalidAudience   Reason = "invalid_audience"
+	ReasonInvalidIssuer     Reason = "invalid_issuer"
+	ReasonUnknownKey        Reason = "unknown_key_id"
+	ReasonMissingSubject    Reason = "missing_subject"
+	ReasonAuthNotConfigured Reason = "auth_not_configured"
+)
+
+// reasonMessages 理由コードごとのエラーメッセージ
+var reasonMessages = map[Reason]string{
+	ReasonMissingToken:      "Authorization token is required",
+	ReasonMalformedHeader:   "Authorization header must be in the form 'Bearer <token>'",
+	ReasonMalformedToken:    "Token is malformed",
+	ReasonInvalidSignature:  "Token signature is invalid",
+	ReasonTokenExpired:      "Token has expired",
+	ReasonTokenNotValidYet:  "Token is not valid yet",
+	ReasonInvalidToken:      "Token is invalid",
+	ReasonInvalidAudience:   "Token audience is not accepted",
+	ReasonInvalidIssuer:     "Token issuer is not accepted",
+	ReasonUnknownKey:        "Token was signed with an unknown key",
+	ReasonMissingSubject:    "Token has no subject",
+	ReasonAuthNotConfigured: "Server authentication is not configured",
+}
+
+// Config 認証設定
+type Config struct {
+	// JWTSecret Supabase の JWT Secret（HS256）
+	JWTSecret string
+	// KeySet 非対称鍵（RS256 / ES256）の検証に使うJWKS
+	KeySet *KeySet
+	// Audience 受け入れる aud クレーム（空なら検証しない）
+	Audience string
+	// Issuer 受け入れる iss クレーム（空なら検証しない）
+	Issuer string
+	// DevAnonymousUserID 設定時、トークンなしのリクエストをこのユーザーとして扱う（開発専用）
+	DevAnonymousUserID string
+	// DevSkipVerify 署名検証なしでトークンを受け入れる（開発専用）
+	// exp は検証するが、偽造できるため app_metadata.role は無視する
+	DevSkipVerify bool
+}
+
+// ConfigFromEnv 環境変数から認証設定を読み込む
+//
+//	SUPABASE_JWT_SECRET           JWT署名検証用のシークレット（HS256）
+//	SUPABASE_URL                  設定時、JWKS URL と iss の既定値をここから導出
+//	SUPABASE_JWKS_URL             非対称鍵のJWKS URL
+//	SUPABASE_JWKS_FILE            ローカルのJWKSファイル（オフラインテスト用、URLより優先）
+//	SUPABASE_JWKS_REFRESH         JWKSの再取得間隔（例: "1h"）
+//	SUPABASE_JWT_AUDIENCE         受け入れる aud（既定: "authenticated"）
+//	SUPABASE_JWT_ISSUER           受け入れる iss
+//	AUTH_DEV_ANONYMOUS_USER_ID    トークンなしのリクエストに割り当てる開発用ユーザーID
+//	AUTH_DEV_SKIP_VERIFY          "true" で署名検証をスキップ（開発用）
+func ConfigFromEnv() Config {
+	cfg := Config{
+		JWTSecret:          os.Getenv("SUPABASE_JWT_SECRET"),
+		Audience:           os.Getenv("SUPABASE_JWT_AUDIENCE"),
+		Issuer:             os.Getenv("SUPABASE_JWT_ISSUER"),
+		DevAnonymousUserID: os.Getenv("AUTH_DEV_ANONYMOUS_USER_ID"),
+		DevSkipVerify:      os.Getenv("AUTH_DEV_SKIP_VERIFY") == "true",
+	}
+	if cfg.Audience == "" {
+		cfg.Audience = "authenticated"
+	}
+
+	jwksURL := os.Getenv("SUPABASE_JWKS_URL")
+	if supabaseURL := strings.TrimSuffix(os.Getenv("SUPABASE_URL"), "/"); supabaseURL != "" {
+		if jwksURL == "" {
+			jwksURL = supabaseURL + "/auth/v1/.well-known/jwks.json"
+		}
+		if cfg.Issuer == "" {
+			cfg.Issuer = supabaseURL + "/auth/v1"
+		}
+	}
+
+	if path := os.Getenv("SUPABASE_JWKS_FILE"); path != "" {
+		cfg.KeySet = NewFileKeySet(path)
+	} else if jwksURL != "" {
+		refresh, _ := time.ParseDuration(os.Getenv("SUPABASE_JWKS_REFRESH"))
+		cfg.KeySet = NewRemoteKeySet(jwksURL, refresh)
+	}
+	return cfg
+}
+
+// Validate 本番（GIN_MODE=release）で開発専用の設定が有効になっていないか検証する
+func (cfg Config) Validate() error {
+	if gin.Mode() != gin.ReleaseMode {
+		return nil
+	}
+	if cfg.DevSkipVerify {
+		return errors.New("AUTH_DEV_SKIP_VERIFY must not be set when GIN_MODE=release")
+	}
+	if cfg.DevAnonymousUserID != "" {
+		return errors.New("AUTH_DEV_ANONYMOUS_USER_ID must not be set when GIN_MODE=release")
+	}
+	return nil
+}
+
+// Middleware JWTトークンからユーザーIDを抽出するミドルウェア
+// 失敗してもリクエストは続行し、理由をコンテキストに記録する（拒否は RequireAuth が行う）
+func Middleware(cfg Config) gin.HandlerFunc {
+	if cfg.DevAnonymousUserID != "" {
+		log.Printf("⚠️  WARNING: dev anonymous user enabled, unauthenticated requests act as %s", cfg.DevAnonymousUserID)
+	}
+	if cfg.KeySet != nil {
+		// 起動時に取得しておく（失敗しても最初の検証時に再試行する）
+		if err := cfg.KeySet.Load(); err != nil {
+			log.Printf("⚠️  WARNING: failed to load JWKS: %v", err)
+		}
+	}
+	if cfg.JWTSecret == "" && cfg.KeySet == nil {
+		if cfg.DevSkipVerify {
+			log.Println("⚠️  WARNING: AUTH_DEV_SKIP_VERIFY is set, skipping JWT signature verification and ignoring roles")
+		} else {
+			log.Println("⚠️  WARNING: neither SUPABASE_JWT_SECRET nor a JWKS is configured, all tokens will be rejected")
+		}
+	}
+
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			if cfg.DevAnonymousUserID != "" {
+				c.Set(ContextUserID, cfg.DevAnonymousUserID)
+			} else {
+				c.Set(ContextAuthFailure, ReasonMissingToken)
+			}
+			c.Next()
+			return
+		}
+
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.Set(ContextAuthFailure, ReasonMalformedHeader)
+			c.Next()
+			return
+		}
+
+		id, reason := cfg.verify(parts[1])
+		if reason != "" {
+			log.Printf("Failed to verify token: %s", reason)
+			c.Set(ContextAuthFailure, reason)
+			c.Next()
+			return
+		}
+
+		c.Set(ContextUserID, id.userID)
+		if id.email != "" {
+			c.Set(ContextUserEmail, id.email)
+		}
+		if id.role != "" {
+			c.Set(ContextUserRole, id.role)
+		}
+		c.Next()
+	}
+}
+
+// identity トークンから取り出したユーザー情報
+type identity struct {
+	userID string // sub
+	email  string
+	role   string // app_metadata.role（Supabase ではサービスロールのみが設定できる）
+}
+
+// verify トークンを検証してユーザー情報を返す
+func (cfg Config) verify(tokenString string) (identity, Reason) {
+	var token *jwt.Token
+	var err error
+	verified := true
+
+	switch {
+	case cfg.JWTSecret != "" || cfg.KeySet != nil:
+		token, err = jwt.Parse(tokenString, cfg.keyFunc, cfg.parserOptions()...)
+	case cfg.DevSkipVerify:
+		// 署名以外（exp / nbf）は通常どおり検証する
+		verified = false
+		token, _, err = new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+		if err == nil {
+			err = jwt.NewValidator(jwt.WithExpirationRequired(), jwt.WithLeeway(30*time.Second)).Validate(token.Claims)
+		}
+	default:
+		return identity{}, ReasonAuthNotConfigured
+	}
+	if err != nil {
+		return identity{}, reasonFor(err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return identity{}, ReasonInvalidToken
+	}
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return identity{}, ReasonMissingSubject
+	}
+	email, _ := claims["email"].(string)
+	// 最上位の role は Supabase のDBロール（authenticated 等）なので、アプリのロールは app_metadata から読む
+	// 署名を検証していないトークンのロールは誰でも付けられるため信用しない
+	var role string
+	if appMetadata, ok := claims["app_metadata"].(map[string]interface{}); ok && verified {
+		role, _ = appMetadata["role"].(string)
+	}
+	return identity{userID: sub, email: email, role: role}, ""
+}
+
+// validMethods 受け入れる署名アルゴリズム
+var validMethods = []string{"HS256", "RS256", "ES256"}
+
+// parserOptions exp は必須、nbf は存在すれば検証、aud / iss は設定時のみ検証
+func (cfg Config) parserOptions() []jwt.ParserOption {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(validMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	return opts
+}
+
+// keyFunc トークンのアルゴリズムに応じて検証鍵を選ぶ
+func (cfg Config) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if cfg.JWTSecret == "" {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(cfg.JWTSecret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if cfg.KeySet == nil {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		kid, _ := token.Header["kid"].(string)
+		return cfg.KeySet.Key(kid, token.Method.Alg())
+	default:
+		return nil, jwt.ErrSignatureInvalid
+	}
+}
+
+// reasonFor jwt のエラーを理由コードに変換
+func reasonFor(err error) Reason {
+	switch {
+	case errors.Is(err, errUnknownKID):
+		return ReasonUnknownKey
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ReasonInvalidAudience
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ReasonInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ReasonMalformedToken
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ReasonTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ReasonTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return ReasonInvalidSignature
+	default:
+		return ReasonInvalidToken
+	}
+}
+
+// RequireAuth 認証済みでないリクエストを401で拒否するミドルウェア
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(ContextUserID) != "" {
+			c.Next()
+			return
+		}
+		abortUnauthenticated(c)
+	}
+}
+
+// abortUnauthenticated 認証失敗の理由を付けて401で拒否する
+func abortUnauthenticated(c *gin.Context) {
+	reason := ReasonMissingToken
+	if v, ok := c.Get(ContextAuthFailure); ok {
+		reason = v.(Reason)
+	}
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"error": reasonMessages[reason],
+		"code":  reason,
+	})
+}
+
+// RoleAdmin 通報の確認・非表示・利用停止ができるロール
+const RoleAdmin = "admin"
+
+// RequireRole 認証済みでないリクエストを401、ロールが一致しないリクエストを403で拒否するミドルウェア
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(ContextUserID) == "" {
+			abortUnauthenticated(c)
+			return
+		}
+		if c.GetString(ContextUserRole) != role {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This action requires the " + role + " role"})
+			return
+		}
+		c.Next()
+	}
+}
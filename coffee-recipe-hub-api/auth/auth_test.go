@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// unsignedToken 署名なし（alg=none）のトークン
+func unsignedToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("failed to build token: %v", err)
+	}
+	return s
+}
+
+func TestDevSkipVerifyIgnoresRole(t *testing.T) {
+	cfg := Config{DevSkipVerify: true}
+	token := unsignedToken(t, jwt.MapClaims{
+		"sub":          "user-1",
+		"exp":          time.Now().Add(time.Hour).Unix(),
+		"app_metadata": map[string]interface{}{"role": RoleAdmin},
+	})
+
+	id, reason := cfg.verify(token)
+	if reason != "" {
+		t.Fatalf("verify failed: %s", reason)
+	}
+	if id.userID != "user-1" {
+		t.Errorf("userID = %q, want user-1", id.userID)
+	}
+	if id.role != "" {
+		t.Errorf("role = %q, want it ignored for unverified tokens", id.role)
+	}
+}
+
+func TestDevSkipVerifyChecksExpiry(t *testing.T) {
+	cfg := Config{DevSkipVerify: true}
+	for name, claims := range map[string]jwt.MapClaims{
+		"expired":     {"sub": "user-1", "exp": time.Now().Add(-time.Hour).Unix()},
+		"missing exp": {"sub": "user-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, reason := cfg.verify(unsignedToken(t, claims)); reason == "" {
+				t.Fatal("verify accepted the token")
+			}
+		})
+	}
+}
+
+func TestVerifiedTokenKeepsRole(t *testing.T) {
+	cfg := Config{JWTSecret: "secret"}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":          "user-1",
+		"exp":          time.Now().Add(time.Hour).Unix(),
+		"app_metadata": map[string]interface{}{"role": RoleAdmin},
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, reason := cfg.verify(token)
+	if reason != "" {
+		t.Fatalf("verify failed: %s", reason)
+	}
+	if id.role != RoleAdmin {
+		t.Errorf("role = %q, want %q", id.role, RoleAdmin)
+	}
+}
+
+func TestValidateRejectsDevSettingsInRelease(t *testing.T) {
+	defer gin.SetMode(gin.Mode())
+	gin.SetMode(gin.ReleaseMode)
+
+	for name, cfg := range map[string]Config{
+		"skip verify":    {JWTSecret: "secret", DevSkipVerify: true},
+		"anonymous user": {JWTSecret: "secret", DevAnonymousUserID: "user-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := cfg.Validate(); err == nil {
+				t.Fatal("Validate accepted a dev setting in release mode")
+			}
+		})
+	}
+	if err := (Config{JWTSecret: "secret"}).Validate(); err != nil {
+		t.Fatalf("Validate rejected a production config: %v", err)
+	}
+
+	gin.SetMode(gin.DebugMode)
+	if err := (Config{DevSkipVerify: true}).Validate(); err != nil {
+		t.Fatalf("Validate rejected dev settings in debug mode: %v", err)
+	}
+}
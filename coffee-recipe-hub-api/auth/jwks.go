@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// errUnknownKID JWKSに該当する kid の鍵がない
+var errUnknownKID = errors.New("no key found for kid")
+
+const (
+	// defaultJWKSRefreshInterval 鍵セットを再取得する間隔
+	defaultJWKSRefreshInterval = time.Hour
+	// minJWKSRefreshInterval 未知の kid による再取得の最短間隔（連続フェッチ防止）
+	minJWKSRefreshInterval = time.Minute
+)
+
+// jwk JWKSドキュメント内の鍵1つ分
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet JWKSから取得した公開鍵を kid ごとにキャッシュする
+// URL または ローカルファイル（オフラインテスト用）から読み込み、期限切れや未知の kid で再取得する
+type KeySet struct {
+	url             string
+	path            string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+
+	// refreshMu 同時の再取得を1回にまとめる（attemptedAt / lastErr も保護する）
+	refreshMu   sync.Mutex
+	attemptedAt time.Time // 最後に取得を試みた時刻（失敗を含む）
+	lastErr     error     // 最後の取得のエラー
+}
+
+// publicKey JWKSの鍵1つ分（alg は JWK に指定があれば、その鍵で受け入れる署名アルゴリズム）
+type publicKey struct {
+	key crypto.PublicKey
+	alg string
+}
+
+// NewRemoteKeySet URLからJWKSを取得する KeySet を作成
+func NewRemoteKeySet(url string, refreshInterval time.Duration) *KeySet {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultJWKSRefreshInterval
+	}
+	return &KeySet{
+		url:             url,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		refreshInterval: refreshInterval,
+	}
+}
+
+// NewFileKeySet ローカルファイルからJWKSを読み込む KeySet を作成
+func NewFileKeySet(path string) *KeySet {
+	return &KeySet{path: path, refreshInterval: defaultJWKSRefreshInterval}
+}
+
+// Load JWKSを取得してキャッシュを置き換える
+func (ks *KeySet) Load() error {
+	ks.refreshMu.Lock()
+	defer ks.refreshMu.Unlock()
+	return ks.load()
+}
+
+// load 呼び出し側で refreshMu を保持すること
+func (ks *KeySet) load() error {
+	ks.attemptedAt = time.Now()
+	keys, err := ks.fetchKeys()
+	ks.lastErr = err
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.fetchedAt = ks.attemptedAt
+	ks.mu.Unlock()
+	return nil
+}
+
+// refresh 最後の試行から minJWKSRefreshInterval 以上経っていれば再取得する（失敗した試行も数える）
+// 呼び出し側で refreshMu を保持すること
+func (ks *KeySet) refresh() error {
+	if !ks.attemptedAt.IsZero() && time.Since(ks.attemptedAt) < minJWKSRefreshInterval {
+		return ks.lastErr
+	}
+	if err := ks.load(); err != nil {
+		log.Printf("⚠️  WARNING: failed to refresh JWKS: %v", err)
+		return err
+	}
+	return nil
+}
+
+// fetchKeys JWKSを取得して kid ごとの公開鍵に変換する
+// 対応していない種類・曲線の鍵や壊れた鍵は読み飛ばす
+func (ks *KeySet) fetchKeys() (map[string]publicKey, error) {
+	data, err := ks.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			log.Printf("⚠️  WARNING: skipping key %q in JWKS: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = publicKey{key: pub, alg: k.Alg}
+	}
+	if len(keys) == 0 && len(doc.Keys) > 0 {
+		return nil, errors.New("JWKS has no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (ks *KeySet) fetch() ([]byte, error) {
+	if ks.path != "" {
+		return os.ReadFile(ks.path)
+	}
+
+	resp, err := ks.client.Get(ks.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// Key kid に対応する、署名アルゴリズム alg のトークンを検証する公開鍵を返す
+// JWK に alg の指定があり、トークンの alg と違う場合はエラー
+// キャッシュが古い場合、または kid が未知の場合は再取得する（鍵のローテーション対応）
+// 再取得は失敗した場合も含めて minJWKSRefreshInterval に1回までで、同時のリクエストでは1回だけ行う
+func (ks *KeySet) Key(kid, alg string) (crypto.PublicKey, error) {
+	ks.mu.RLock()
+	key, ok := ks.lookup(kid)
+	age := time.Since(ks.fetchedAt)
+	ks.mu.RUnlock()
+
+	if ok && age < ks.refreshInterval {
+		return key.verify(kid, alg)
+	}
+
+	if ok {
+		// キャッシュ済みの鍵があれば、他のリクエストが再取得中でも待たずにそれを使う
+		// 再取得に失敗した場合は refresh でログに残し、キャッシュ済みの鍵を使い続ける
+		if ks.refreshMu.TryLock() {
+			_ = ks.refresh()
+			ks.refreshMu.Unlock()
+		}
+	} else {
+		ks.refreshMu.Lock()
+		err := ks.refresh()
+		ks.refreshMu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	if key, ok := ks.lookup(kid); ok {
+		return key.verify(kid, alg)
+	}
+	return nil, errUnknownKID
+}
+
+// verify 鍵の alg がトークンの alg と一致すれば公開鍵を返す（JWK に alg の指定がなければ通す）
+func (k publicKey) verify(kid, alg string) (crypto.PublicKey, error) {
+	if k.alg != "" && k.alg != alg {
+		return nil, fmt.Errorf("key %q is for %s, not %s", kid, k.alg, alg)
+	}
+	return k.key, nil
+}
+
+// lookup 呼び出し側でロックを保持すること
+// kid なしのトークンは、鍵が1つだけの場合に限りその鍵を使う
+func (ks *KeySet) lookup(kid string) (publicKey, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+// publicKey JWKを公開鍵に変換（RSA / EC に対応）
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URL(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URL(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URL(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, errors.New("EC coordinate too large")
+		}
+		// SEC1 非圧縮形式 (0x04 || X || Y) に変換して曲線上の点か検証
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBase64URL(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer JWKSを返すテスト用サーバー（fail が true の間は 503 を返す）
+type jwksServer struct {
+	*httptest.Server
+	fetches atomic.Int32
+	fail    atomic.Bool
+}
+
+func newJWKSServer(t *testing.T, keys ...jwk) *jwksServer {
+	t.Helper()
+	body, err := json.Marshal(map[string][]jwk{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &jwksServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		if s.fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		// 同時の再取得が1回にまとまることを確かめられるよう、少し待ってから返す
+		time.Sleep(20 * time.Millisecond)
+		w.Write(body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func rsaJWK(t *testing.T, kid string) jwk {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := base64.RawURLEncoding.EncodeToString
+	return jwk{Kid: kid, Kty: "RSA", Alg: "RS256", N: enc(key.N.Bytes()), E: enc(big.NewInt(int64(key.E)).Bytes())}
+}
+
+func TestLoadSkipsUnsupportedKeys(t *testing.T) {
+	srv := newJWKSServer(t,
+		jwk{Kid: "okp", Kty: "OKP", Crv: "Ed25519", X: "AAAA"},
+		jwk{Kid: "secp256k1", Kty: "EC", Crv: "secp256k1", X: "AAAA", Y: "AAAA"},
+		rsaJWK(t, "rsa"),
+	)
+	ks := NewRemoteKeySet(srv.URL, time.Hour)
+	if err := ks.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if _, err := ks.Key("rsa", "RS256"); err != nil {
+		t.Errorf("supported key was not loaded: %v", err)
+	}
+	if _, err := ks.Key("okp", "RS256"); err == nil {
+		t.Error("unsupported key was loaded")
+	}
+}
+
+func TestFailedLoadIsRateLimited(t *testing.T) {
+	srv := newJWKSServer(t, rsaJWK(t, "rsa"))
+	srv.fail.Store(true)
+	ks := NewRemoteKeySet(srv.URL, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := ks.Key("rsa", "RS256"); err == nil {
+			t.Fatal("Key succeeded while the JWKS endpoint is down")
+		}
+	}
+	if n := srv.fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times while the endpoint is down, want 1", n)
+	}
+}
+
+func TestConcurrentRefreshesCollapse(t *testing.T) {
+	srv := newJWKSServer(t, rsaJWK(t, "rsa"))
+	ks := NewRemoteKeySet(srv.URL, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ks.Key("rsa", "RS256"); err != nil {
+				t.Errorf("Key failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := srv.fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times for concurrent requests, want 1", n)
+	}
+}
+
+func TestKeyRejectsMismatchedAlg(t *testing.T) {
+	pinned := rsaJWK(t, "pinned")
+	unpinned := rsaJWK(t, "unpinned")
+	unpinned.Alg = ""
+	ks := NewRemoteKeySet(newJWKSServer(t, pinned, unpinned).URL, time.Hour)
+
+	if _, err := ks.Key("pinned", "RS256"); err != nil {
+		t.Errorf("Key rejected the key's own alg: %v", err)
+	}
+	if _, err := ks.Key("pinned", "RS512"); err == nil {
+		t.Error("Key accepted a token alg that differs from the JWK alg")
+	}
+	if _, err := ks.Key("unpinned", "RS512"); err != nil {
+		t.Errorf("Key rejected a JWK without alg: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package brewing
+
+import (
+	"slices"
+
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+// Diff 2つの版の差分（ステップは order で対応付ける）
+func Diff(from, to *models.RecipeRevision) models.RecipeDiff {
+	diff := models.RecipeDiff{
+		RecipeID:    to.RecipeID,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Fields:      []models.RecipeFieldChange{},
+		Steps:       []models.RecipeStepChange{},
+	}
+
+	field := func(name string, a, b interface{}, changed bool) {
+		if changed {
+			diff.Fields = append(diff.Fields, models.RecipeFieldChange{Field: name, From: a, To: b})
+		}
+	}
+	field("title", from.Title, to.Title, from.Title != to.Title)
+	field("equipment", from.Equipment, to.Equipment, from.Equipment != to.Equipment)
+	field("coffeeGrams", from.CoffeeGrams, to.CoffeeGrams, from.CoffeeGrams != to.CoffeeGrams)
+	field("totalWaterMl", from.TotalWaterMl, to.TotalWaterMl, from.TotalWaterMl != to.TotalWaterMl)
+	field("waterTemperature", from.WaterTemperature, to.WaterTemperature, from.WaterTemperature != to.WaterTemperature)
+	field("grindSize", from.GrindSize, to.GrindSize, from.GrindSize != to.GrindSize)
+	field("stepWaterMode", modeOf(from.StepWaterMode), modeOf(to.StepWaterMode), modeOf(from.StepWaterMode) != modeOf(to.StepWaterMode))
+	field("tags", nonNil(from.Tags), nonNil(to.Tags), !slices.Equal(from.Tags, to.Tags))
+
+	diff.Steps = diffSteps(from.Steps, to.Steps)
+	return diff
+}
+
+// Revision レシピの現在の内容を版として取り出す（ID・作成日時は設定しない）
+func Revision(recipe *models.Recipe) models.RecipeRevision {
+	return models.RecipeRevision{
+		RecipeID:         recipe.ID,
+		Version:          recipe.Version,
+		Title:            recipe.Title,
+		Equipment:        recipe.Equipment,
+		CoffeeGrams:      recipe.CoffeeGrams,
+		TotalWaterMl:     recipe.TotalWaterMl,
+		WaterTemperature: recipe.WaterTemperature,
+		GrindSize:        recipe.GrindSize,
+		StepWaterMode:    recipe.StepWaterMode,
+		Steps:            append([]models.RecipeStep(nil), recipe.Steps...),
+		Tags:             append([]string(nil), recipe.Tags...),
+	}
+}
+
+// Changed 内容に差分があるか
+func Changed(from, to *models.RecipeRevision) bool {
+	d := Diff(from, to)
+	return len(d.Fields) > 0 || len(d.Steps) > 0
+}
+
+func diffSteps(from, to []models.RecipeStep) []models.RecipeStepChange {
+	changes := []models.RecipeStepChange{}
+	before := make(map[int]models.RecipeStep, len(from))
+	for _, step := range from {
+		before[step.Order] = step
+	}
+	after := make(map[int]models.RecipeStep, len(to))
+	for _, step := range to {
+		after[step.Order] = step
+	}
+
+	orders := make([]int, 0, len(before)+len(after))
+	for order := range before {
+		orders = append(orders, order)
+	}
+	for order := range after {
+		if _, ok := before[order]; !ok {
+			orders = append(orders, order)
+		}
+	}
+	slices.Sort(orders)
+
+	for _, order := range orders {
+		a, hadA := before[order]
+		b, hasB := after[order]
+		switch {
+		case !hasB:
+			changes = append(changes, models.RecipeStepChange{Order: order, Change: models.StepRemoved, From: &a})
+		case !hadA:
+			changes = append(changes, models.RecipeStepChange{Order: order, Change: models.StepAdded, To: &b})
+		default:
+			if fields := changedStepFields(a, b); len(fields) > 0 {
+				changes = append(changes, models.RecipeStepChange{
+					Order: order, Change: models.StepModified, From: &a, To: &b, Fields: fields,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// changedStepFields 保存される項目のうち変わったもの（算出値は比較しない）
+func changedStepFields(a, b models.RecipeStep) []string {
+	var fields []string
+	if a.Label != b.Label {
+		fields = append(fields, "label")
+	}
+	if a.TimeSeconds != b.TimeSeconds {
+		fields = append(fields, "timeSeconds")
+	}
+	if a.WaterMl != b.WaterMl {
+		fields = append(fields, "waterMl")
+	}
+	if a.Notes != b.Notes {
+		fields = append(fields, "notes")
+	}
+	return fields
+}
+
+// nonNil JSONで null ではなく [] として返す
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+// Package brewing レシピの分量計算（スケーリング）・版の差分と器具ごとの仕様
+package brewing
+
+import "github.com/coffee-recipe-hub/api/models"
+
+// EquipmentSpec 器具ごとの容量と妥当な範囲
+// 値は一般的なサイズ（V60 は 02、Chemex は 6カップ）を基準にした目安
+type EquipmentSpec struct {
+	MaxWaterMl     int     `json:"maxWaterMl"`
+	MinCoffeeGrams float64 `json:"minCoffeeGrams"`
+	MaxCoffeeGrams float64 `json:"maxCoffeeGrams"`
+	MinTemperature int     `json:"minTemperature"` // ℃
+	MaxTemperature int     `json:"maxTemperature"`
+}
+
+var equipmentSpecs = map[models.Equipment]EquipmentSpec{
+	models.EquipmentV60:         {MaxWaterMl: 600, MinCoffeeGrams: 8, MaxCoffeeGrams: 40, MinTemperature: 70, MaxTemperature: 100},
+	models.EquipmentKalitaWave:  {MaxWaterMl: 600, MinCoffeeGrams: 8, MaxCoffeeGrams: 40, MinTemperature: 70, MaxTemperature: 100},
+	models.EquipmentChemex:      {MaxWaterMl: 900, MinCoffeeGrams: 15, MaxCoffeeGrams: 60, MinTemperature: 70, MaxTemperature: 100},
+	models.EquipmentAeropress:   {MaxWaterMl: 250, MinCoffeeGrams: 8, MaxCoffeeGrams: 35, MinTemperature: 60, MaxTemperature: 100},
+	models.EquipmentFrenchPress: {MaxWaterMl: 1000, MinCoffeeGrams: 10, MaxCoffeeGrams: 70, MinTemperature: 80, MaxTemperature: 100},
+	models.EquipmentClever:      {MaxWaterMl: 500, MinCoffeeGrams: 10, MaxCoffeeGrams: 35, MinTemperature: 70, MaxTemperature: 100},
+}
+
+// genericSpec OTHER など個別の仕様がない器具の範囲（容量の警告は出さない）
+var genericSpec = EquipmentSpec{MinCoffeeGrams: 1, MaxCoffeeGrams: 1000, MinTemperature: 0, MaxTemperature: 100}
+
+// SpecFor 器具の容量（OTHER など仕様がない器具は false）
+func SpecFor(equipment models.Equipment) (EquipmentSpec, bool) {
+	spec, ok := equipmentSpecs[equipment]
+	return spec, ok
+}
+
+// RangeFor 豆量・湯温の妥当な範囲（仕様がない器具は汎用の範囲）
+func RangeFor(equipment models.Equipment) EquipmentSpec {
+	if spec, ok := equipmentSpecs[equipment]; ok {
+		return spec
+	}
+	return genericSpec
+}
+
+// KnownEquipment 定義済みの器具か
+func KnownEquipment(equipment models.Equipment) bool {
+	_, ok := equipmentSpecs[equipment]
+	return ok || equipment == models.EquipmentOther
+}
@@ -0,0 +1,134 @@
+package brewing
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+// ScaleTarget スケーリングの基準（いずれか1つを指定する）
+type ScaleTarget struct {
+	CoffeeGrams  float64 // 豆量に合わせて湯量も比例させる
+	TotalWaterMl int     // 湯量に合わせて豆量も比例させる
+	Ratio        float64 // 豆量はそのままで湯量を 豆量×Ratio にする
+}
+
+// ステップの湯量の丸め方
+const (
+	// RoundingNearest1ml 少量の注湯（蒸らしなど）は1ml単位
+	RoundingNearest1ml = "nearest_1ml"
+	// RoundingNearest5ml それ以外はスケールで読みやすい5ml単位
+	RoundingNearest5ml = "nearest_5ml"
+	// RoundingMatchTotal 累計が総湯量に達するステップは総湯量にそろえる
+	RoundingMatchTotal = "match_total"
+)
+
+// smallPourMl 1投の量がこれ未満のステップは1ml単位で丸める
+const smallPourMl = 50
+
+// Scale レシピの豆量・湯量・ステップの湯量を比例させて計算し直す
+func Scale(recipe *models.Recipe, target ScaleTarget) (*models.ScaledRecipe, error) {
+	if recipe.CoffeeGrams <= 0 || recipe.TotalWaterMl <= 0 {
+		return nil, fmt.Errorf("recipe has no coffee or water amount to scale from")
+	}
+
+	coffeeFactor, waterFactor := 1.0, 1.0
+	switch {
+	case target.CoffeeGrams > 0:
+		coffeeFactor = target.CoffeeGrams / recipe.CoffeeGrams
+		waterFactor = coffeeFactor
+	case target.TotalWaterMl > 0:
+		waterFactor = float64(target.TotalWaterMl) / float64(recipe.TotalWaterMl)
+		coffeeFactor = waterFactor
+	case target.Ratio > 0:
+		waterFactor = recipe.CoffeeGrams * target.Ratio / float64(recipe.TotalWaterMl)
+	default:
+		return nil, fmt.Errorf("scale target is required")
+	}
+
+	scaled := &models.ScaledRecipe{
+		RecipeID:             recipe.ID,
+		Title:                recipe.Title,
+		Equipment:            recipe.Equipment,
+		CoffeeGrams:          math.Round(recipe.CoffeeGrams*coffeeFactor*10) / 10,
+		TotalWaterMl:         int(math.Round(float64(recipe.TotalWaterMl) * waterFactor)),
+		WaterTemperature:     recipe.WaterTemperature,
+		GrindSize:            recipe.GrindSize,
+		ScaleFactor:          waterFactor,
+		OriginalCoffeeGrams:  recipe.CoffeeGrams,
+		OriginalTotalWaterMl: recipe.TotalWaterMl,
+		Steps:                make([]models.ScaledRecipeStep, 0, len(recipe.Steps)),
+		Warnings:             []models.ScaleWarning{},
+	}
+	scaled.Ratio = math.Round(float64(scaled.TotalWaterMl)/scaled.CoffeeGrams*10) / 10
+	scaled.StepWaterMode = modeOf(recipe.StepWaterMode)
+
+	// 累計湯量を比例させて丸め、1投ごとの量は累計の差から求める（丸め誤差が積み重ならない）
+	original := cumulativeWater(recipe.Steps, recipe.StepWaterMode)
+	steps := make([]models.RecipeStep, len(recipe.Steps))
+	totals := make([]int, len(recipe.Steps))
+	rounding := make([]string, len(recipe.Steps))
+	prevOriginal, prev := 0, 0
+	for i, step := range recipe.Steps {
+		steps[i] = step
+		totals[i], rounding[i] = scaleCumulative(original[i], original[i]-prevOriginal, prev,
+			recipe.TotalWaterMl, scaled.TotalWaterMl, waterFactor)
+		prevOriginal, prev = original[i], totals[i]
+	}
+	setComputedWater(steps, totals)
+
+	for i, step := range steps {
+		step.WaterMl = waterInMode(step, scaled.StepWaterMode)
+		scaled.Steps = append(scaled.Steps, models.ScaledRecipeStep{
+			RecipeStep:      step,
+			OriginalWaterMl: recipe.Steps[i].WaterMl,
+			Rounding:        rounding[i],
+		})
+	}
+
+	scaled.Warnings = capacityWarnings(scaled)
+	return scaled, nil
+}
+
+// scaleCumulative ステップ終了時点の累計湯量を比例させて丸める
+// 丸め単位はそのステップで注ぐ量で決め、結果は前のステップ以上・総湯量以下にする
+func scaleCumulative(cumulative, pour, prev, totalWaterMl, scaledTotal int, factor float64) (int, string) {
+	if pour == 0 {
+		return prev, RoundingNearest1ml
+	}
+	if cumulative == totalWaterMl {
+		return scaledTotal, RoundingMatchTotal
+	}
+
+	exact := float64(cumulative) * factor
+	rounded, rule := int(math.Round(exact)), RoundingNearest1ml
+	if float64(pour)*factor >= smallPourMl {
+		rounded, rule = int(math.Round(exact/5))*5, RoundingNearest5ml
+	}
+	return min(max(rounded, prev), scaledTotal), rule
+}
+
+// capacityWarnings 器具の容量を超える分量への警告
+func capacityWarnings(scaled *models.ScaledRecipe) []models.ScaleWarning {
+	warnings := []models.ScaleWarning{}
+	spec, ok := SpecFor(scaled.Equipment)
+	if !ok {
+		return warnings
+	}
+	if scaled.TotalWaterMl > spec.MaxWaterMl {
+		warnings = append(warnings, models.ScaleWarning{
+			Code:    models.ScaleWarningWaterCapacity,
+			Message: fmt.Sprintf("%s holds about %d ml; %d ml may need to be brewed in batches", scaled.Equipment, spec.MaxWaterMl, scaled.TotalWaterMl),
+			Limit:   float64(spec.MaxWaterMl),
+		})
+	}
+	if scaled.CoffeeGrams > spec.MaxCoffeeGrams {
+		warnings = append(warnings, models.ScaleWarning{
+			Code:    models.ScaleWarningCoffeeCapacity,
+			Message: fmt.Sprintf("%s fits about %g g of coffee; %g g may overflow the filter", scaled.Equipment, spec.MaxCoffeeGrams, scaled.CoffeeGrams),
+			Limit:   spec.MaxCoffeeGrams,
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,73 @@
+package brewing
+
+import "github.com/coffee-recipe-hub/api/models"
+
+// modeOf 未設定は per_pour として扱う
+func modeOf(mode models.StepWaterMode) models.StepWaterMode {
+	if mode == models.StepWaterCumulative {
+		return mode
+	}
+	return models.StepWaterPerPour
+}
+
+// cumulativeWater 各ステップ終了時点の累計湯量
+// cumulative モードで0のステップは注がない（累計は前のステップのまま）
+func cumulativeWater(steps []models.RecipeStep, mode models.StepWaterMode) []int {
+	totals := make([]int, len(steps))
+	total := 0
+	for i, step := range steps {
+		if modeOf(mode) == models.StepWaterCumulative {
+			if step.WaterMl > 0 {
+				total = step.WaterMl
+			}
+		} else {
+			total += step.WaterMl
+		}
+		totals[i] = total
+	}
+	return totals
+}
+
+// AnnotateWater 各ステップに累計湯量と1投ごとの量を設定する
+func AnnotateWater(recipe *models.Recipe) {
+	recipe.StepWaterMode = modeOf(recipe.StepWaterMode)
+	setComputedWater(recipe.Steps, cumulativeWater(recipe.Steps, recipe.StepWaterMode))
+}
+
+// setComputedWater 累計湯量から CumulativeWaterMl / PourWaterMl を設定する
+func setComputedWater(steps []models.RecipeStep, totals []int) {
+	prev := 0
+	for i := range steps {
+		steps[i].CumulativeWaterMl = totals[i]
+		steps[i].PourWaterMl = totals[i] - prev
+		prev = totals[i]
+	}
+}
+
+// ConvertWaterMode ステップの WaterMl を指定したモードの値に書き換える
+func ConvertWaterMode(recipe *models.Recipe, mode models.StepWaterMode) {
+	totals := cumulativeWater(recipe.Steps, recipe.StepWaterMode)
+	recipe.StepWaterMode = modeOf(mode)
+	setComputedWater(recipe.Steps, totals)
+	for i := range recipe.Steps {
+		recipe.Steps[i].WaterMl = waterInMode(recipe.Steps[i], recipe.StepWaterMode)
+	}
+}
+
+// waterInMode 算出済みの値からモードに応じた WaterMl を返す
+// cumulative では注がないステップを0で表す
+func waterInMode(step models.RecipeStep, mode models.StepWaterMode) int {
+	if mode == models.StepWaterPerPour {
+		return step.PourWaterMl
+	}
+	if step.PourWaterMl == 0 {
+		return 0
+	}
+	return step.CumulativeWaterMl
+}
+
+// AnnotateRevisionWater 版の各ステップに累計湯量と1投ごとの量を設定する
+func AnnotateRevisionWater(rev *models.RecipeRevision) {
+	rev.StepWaterMode = modeOf(rev.StepWaterMode)
+	setComputedWater(rev.Steps, cumulativeWater(rev.Steps, rev.StepWaterMode))
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS recipe_likes;
+DROP TABLE IF EXISTS brew_logs;
+DROP TABLE IF EXISTS recipes;
+DROP TABLE IF EXISTS beans;
@@ -1,5 +1,5 @@
--- Coffee Recipe Hub データベーススキーマ
--- Supabase SQL Editorで実行してください
+-- Coffee Recipe Hub 初期スキーマ
+-- 手動で schema.sql を適用済みのDBでもそのまま適用できるよう冪等にしている
 
 -- 豆テーブル
 CREATE TABLE IF NOT EXISTS beans (
@@ -50,50 +50,63 @@ CREATE TABLE IF NOT EXISTS brew_logs (
     created_at TIMESTAMPTZ DEFAULT NOW()
 );
 
+-- いいねテーブル
+CREATE TABLE IF NOT EXISTS recipe_likes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES auth.users(id) ON DELETE CASCADE,
+    recipe_id UUID REFERENCES recipes(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(user_id, recipe_id)
+);
+
 -- インデックス
 CREATE INDEX IF NOT EXISTS idx_beans_user_id ON beans(user_id);
 CREATE INDEX IF NOT EXISTS idx_recipes_user_id ON recipes(user_id);
 CREATE INDEX IF NOT EXISTS idx_recipes_is_public ON recipes(is_public);
 CREATE INDEX IF NOT EXISTS idx_brew_logs_user_id ON brew_logs(user_id);
+CREATE INDEX IF NOT EXISTS idx_recipe_likes_user_id ON recipe_likes(user_id);
+CREATE INDEX IF NOT EXISTS idx_recipe_likes_recipe_id ON recipe_likes(recipe_id);
 
 -- RLS (Row Level Security) ポリシー
 ALTER TABLE beans ENABLE ROW LEVEL SECURITY;
 ALTER TABLE recipes ENABLE ROW LEVEL SECURITY;
 ALTER TABLE brew_logs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE recipe_likes ENABLE ROW LEVEL SECURITY;
 
 -- 豆: ユーザーは自分のデータのみアクセス可能
+DROP POLICY IF EXISTS "Users can view own beans" ON beans;
+DROP POLICY IF EXISTS "Users can insert own beans" ON beans;
+DROP POLICY IF EXISTS "Users can update own beans" ON beans;
+DROP POLICY IF EXISTS "Users can delete own beans" ON beans;
 CREATE POLICY "Users can view own beans" ON beans FOR SELECT USING (auth.uid() = user_id);
 CREATE POLICY "Users can insert own beans" ON beans FOR INSERT WITH CHECK (auth.uid() = user_id);
 CREATE POLICY "Users can update own beans" ON beans FOR UPDATE USING (auth.uid() = user_id);
 CREATE POLICY "Users can delete own beans" ON beans FOR DELETE USING (auth.uid() = user_id);
 
 -- レシピ: 自分のデータ + 公開レシピを閲覧可能
+DROP POLICY IF EXISTS "Users can view own and public recipes" ON recipes;
+DROP POLICY IF EXISTS "Users can insert own recipes" ON recipes;
+DROP POLICY IF EXISTS "Users can update own recipes" ON recipes;
+DROP POLICY IF EXISTS "Users can delete own recipes" ON recipes;
 CREATE POLICY "Users can view own and public recipes" ON recipes FOR SELECT USING (auth.uid() = user_id OR is_public = TRUE);
 CREATE POLICY "Users can insert own recipes" ON recipes FOR INSERT WITH CHECK (auth.uid() = user_id);
 CREATE POLICY "Users can update own recipes" ON recipes FOR UPDATE USING (auth.uid() = user_id);
 CREATE POLICY "Users can delete own recipes" ON recipes FOR DELETE USING (auth.uid() = user_id);
 
 -- 抽出ログ: ユーザーは自分のデータのみアクセス可能
+DROP POLICY IF EXISTS "Users can view own brew_logs" ON brew_logs;
+DROP POLICY IF EXISTS "Users can insert own brew_logs" ON brew_logs;
+DROP POLICY IF EXISTS "Users can update own brew_logs" ON brew_logs;
+DROP POLICY IF EXISTS "Users can delete own brew_logs" ON brew_logs;
 CREATE POLICY "Users can view own brew_logs" ON brew_logs FOR SELECT USING (auth.uid() = user_id);
 CREATE POLICY "Users can insert own brew_logs" ON brew_logs FOR INSERT WITH CHECK (auth.uid() = user_id);
 CREATE POLICY "Users can update own brew_logs" ON brew_logs FOR UPDATE USING (auth.uid() = user_id);
 CREATE POLICY "Users can delete own brew_logs" ON brew_logs FOR DELETE USING (auth.uid() = user_id);
 
--- いいねテーブル
-CREATE TABLE IF NOT EXISTS recipe_likes (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    user_id UUID REFERENCES auth.users(id) ON DELETE CASCADE,
-    recipe_id UUID REFERENCES recipes(id) ON DELETE CASCADE,
-    created_at TIMESTAMPTZ DEFAULT NOW(),
-    UNIQUE(user_id, recipe_id)
-);
-
-CREATE INDEX IF NOT EXISTS idx_recipe_likes_user_id ON recipe_likes(user_id);
-CREATE INDEX IF NOT EXISTS idx_recipe_likes_recipe_id ON recipe_likes(recipe_id);
-
-ALTER TABLE recipe_likes ENABLE ROW LEVEL SECURITY;
-
 -- いいね: ユーザーは自分のいいねのみ管理可能、閲覧は全員可能
+DROP POLICY IF EXISTS "Users can view all likes" ON recipe_likes;
+DROP POLICY IF EXISTS "Users can insert own likes" ON recipe_likes;
+DROP POLICY IF EXISTS "Users can delete own likes" ON recipe_likes;
 CREATE POLICY "Users can view all likes" ON recipe_likes FOR SELECT USING (TRUE);
 CREATE POLICY "Users can insert own likes" ON recipe_likes FOR INSERT WITH CHECK (auth.uid() = user_id);
 CREATE POLICY "Users can delete own likes" ON recipe_likes FOR DELETE USING (auth.uid() = user_id);
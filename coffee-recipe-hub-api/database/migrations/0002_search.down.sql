@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_beans_search;
+DROP INDEX IF EXISTS idx_recipes_search;
+ALTER TABLE beans DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE recipes DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS search_recipe_steps(JSONB);
+DROP FUNCTION IF EXISTS search_text_array(TEXT[]);
@@ -0,0 +1,29 @@
+-- 全文検索: レシピ（タイトル・タグ・ステップ）と豆（名前・焙煎所・産地・フレーバー）
+-- 日本語を含むため辞書は 'simple' を使う
+
+-- 生成列は IMMUTABLE な式しか使えないため、配列・JSONB の連結を関数に切り出す
+CREATE OR REPLACE FUNCTION search_text_array(arr TEXT[]) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT COALESCE(array_to_string(arr, ' '), '') $$;
+
+CREATE OR REPLACE FUNCTION search_recipe_steps(steps JSONB) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+        SELECT COALESCE(string_agg(COALESCE(s->>'label', '') || ' ' || COALESCE(s->>'notes', ''), ' '), '')
+        FROM jsonb_array_elements(CASE WHEN jsonb_typeof(steps) = 'array' THEN steps ELSE '[]'::jsonb END) AS s
+    $$;
+
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('simple', search_text_array(tags)), 'B') ||
+    setweight(to_tsvector('simple', search_recipe_steps(steps)), 'C')
+) STORED;
+
+ALTER TABLE beans ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(roaster_name, '') || ' ' || COALESCE(origin, '')), 'B') ||
+    setweight(to_tsvector('simple', search_text_array(flavor_notes)), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_recipes_search ON recipes USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_beans_search ON beans USING GIN (search_vector);
@@ -0,0 +1 @@
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS stock_deducted_grams;
@@ -0,0 +1,3 @@
+-- 抽出ログごとに実際に在庫から差し引いた量を記録し、削除・変更時に正確に戻せるようにする
+-- 既存のログは差し引き量が不明なため 0（削除しても在庫は戻らない）
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS stock_deducted_grams INTEGER NOT NULL DEFAULT 0;
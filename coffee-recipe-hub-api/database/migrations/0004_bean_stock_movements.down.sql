@@ -0,0 +1,7 @@
+ALTER TABLE beans ADD COLUMN IF NOT EXISTS stock_grams INTEGER DEFAULT 0;
+
+UPDATE beans SET stock_grams = GREATEST(m.total, 0)
+FROM (SELECT bean_id, SUM(grams) AS total FROM bean_stock_movements GROUP BY bean_id) m
+WHERE m.bean_id = beans.id;
+
+DROP TABLE IF EXISTS bean_stock_movements;
@@ -0,0 +1,24 @@
+-- 豆の在庫台帳: 在庫は beans.stock_grams ではなく入出庫の合計から求める
+CREATE TABLE IF NOT EXISTS bean_stock_movements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    bean_id UUID NOT NULL REFERENCES beans(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES auth.users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('purchase', 'brew', 'adjustment', 'waste')),
+    grams INTEGER NOT NULL, -- 在庫の増減（減少は負）
+    brew_log_id UUID REFERENCES brew_logs(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp() -- 同一トランザクション内の順序を保つ
+);
+
+CREATE INDEX IF NOT EXISTS idx_bean_stock_movements_bean_id ON bean_stock_movements(bean_id, created_at);
+
+ALTER TABLE bean_stock_movements ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Users can view own stock movements" ON bean_stock_movements FOR SELECT USING (auth.uid() = user_id);
+
+-- 既存の在庫を期首残高として移行
+INSERT INTO bean_stock_movements (bean_id, user_id, type, grams, note, created_at)
+SELECT id, user_id, 'adjustment', stock_grams, 'Opening balance', COALESCE(created_at, NOW())
+FROM beans
+WHERE COALESCE(stock_grams, 0) > 0;
+
+ALTER TABLE beans DROP COLUMN stock_grams;
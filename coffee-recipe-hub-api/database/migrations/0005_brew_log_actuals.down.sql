@@ -0,0 +1,5 @@
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS yield_grams;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS grind_setting;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS water_temperature;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS water_ml;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS coffee_grams;
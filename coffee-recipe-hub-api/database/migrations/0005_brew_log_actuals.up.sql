@@ -0,0 +1,15 @@
+-- 抽出ログに実際の抽出条件を記録する（在庫は実際の豆量から差し引く）
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS coffee_grams DECIMAL(5,1);
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS water_ml INTEGER;
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS water_temperature INTEGER;
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS grind_setting VARCHAR(50);
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS yield_grams DECIMAL(6,1);
+
+-- 既存のログはレシピの値で補完
+UPDATE brew_logs SET
+    coffee_grams = r.coffee_grams,
+    water_ml = r.total_water_ml,
+    water_temperature = r.water_temperature,
+    grind_setting = r.grind_size
+FROM recipes r
+WHERE r.id = brew_logs.recipe_id AND brew_logs.coffee_grams IS NULL;
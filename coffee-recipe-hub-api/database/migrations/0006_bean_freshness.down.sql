@@ -0,0 +1,3 @@
+ALTER TABLE beans DROP COLUMN IF EXISTS stale_days;
+ALTER TABLE beans DROP COLUMN IF EXISTS peak_end_days;
+ALTER TABLE beans DROP COLUMN IF EXISTS rest_days;
@@ -0,0 +1,4 @@
+-- 飲み頃の区切り（焙煎日からの日数）の豆ごとの上書き。NULLなら焙煎度の既定値を使う
+ALTER TABLE beans ADD COLUMN IF NOT EXISTS rest_days INTEGER CHECK (rest_days >= 0);
+ALTER TABLE beans ADD COLUMN IF NOT EXISTS peak_end_days INTEGER CHECK (peak_end_days >= 0);
+ALTER TABLE beans ADD COLUMN IF NOT EXISTS stale_days INTEGER CHECK (stale_days >= 0);
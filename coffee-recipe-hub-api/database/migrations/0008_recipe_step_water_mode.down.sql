@@ -0,0 +1 @@
+ALTER TABLE recipes DROP COLUMN IF EXISTS step_water_mode;
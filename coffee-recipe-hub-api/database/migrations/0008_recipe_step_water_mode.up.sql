@@ -0,0 +1,3 @@
+-- steps[].waterMl の意味を明示する（既存のレシピはアプリが1投ごとの量として扱っていた）
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS step_water_mode VARCHAR(20) NOT NULL DEFAULT 'per_pour'
+    CHECK (step_water_mode IN ('per_pour', 'cumulative'));
@@ -0,0 +1,3 @@
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS recipe_version;
+ALTER TABLE recipes DROP COLUMN IF EXISTS current_version;
+DROP TABLE IF EXISTS recipe_revisions;
@@ -0,0 +1,38 @@
+-- レシピの内容の変更履歴（各版は作成後に書き換えない）
+CREATE TABLE IF NOT EXISTS recipe_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recipe_id UUID NOT NULL REFERENCES recipes(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL CHECK (version > 0),
+    title VARCHAR(255) NOT NULL,
+    equipment VARCHAR(50),
+    coffee_grams DECIMAL(5,1),
+    total_water_ml INTEGER,
+    water_temperature INTEGER,
+    grind_size VARCHAR(50),
+    step_water_mode VARCHAR(20) NOT NULL,
+    steps JSONB,
+    tags TEXT[],
+    -- 過去の版を復元して作った版なら復元元の版
+    restored_from INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (recipe_id, version)
+);
+
+ALTER TABLE recipe_revisions ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Users can view revisions of own and public recipes" ON recipe_revisions FOR SELECT
+    USING (EXISTS (SELECT 1 FROM recipes r WHERE r.id = recipe_id AND (auth.uid() = r.user_id OR r.is_public = TRUE)));
+
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS current_version INTEGER NOT NULL DEFAULT 1;
+
+-- 抽出したときのレシピの版
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS recipe_version INTEGER;
+
+-- 既存のレシピは現在の内容を第1版とする
+INSERT INTO recipe_revisions (recipe_id, version, title, equipment, coffee_grams, total_water_ml, water_temperature,
+                              grind_size, step_water_mode, steps, tags, created_at)
+SELECT id, 1, title, equipment, coffee_grams, total_water_ml, water_temperature,
+       grind_size, step_water_mode, steps, tags, COALESCE(updated_at, created_at, NOW())
+FROM recipes
+ON CONFLICT (recipe_id, version) DO NOTHING;
+
+UPDATE brew_logs SET recipe_version = 1 WHERE recipe_id IS NOT NULL AND recipe_version IS NULL;
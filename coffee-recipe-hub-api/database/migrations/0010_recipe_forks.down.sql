@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_recipes_forked_from;
+ALTER TABLE recipes DROP COLUMN IF EXISTS fork_count;
+ALTER TABLE recipes DROP COLUMN IF EXISTS original_author_name;
+ALTER TABLE recipes DROP COLUMN IF EXISTS original_author_id;
+ALTER TABLE recipes DROP COLUMN IF EXISTS forked_from_version;
+ALTER TABLE recipes DROP COLUMN IF EXISTS forked_from_recipe_id;
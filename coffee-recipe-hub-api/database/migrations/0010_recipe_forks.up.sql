@@ -0,0 +1,9 @@
+-- 公開レシピを自分用にコピー（フォーク）したときの元レシピと作者
+-- 元レシピが削除されても作者の表示は残す
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS forked_from_recipe_id UUID REFERENCES recipes(id) ON DELETE SET NULL;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS forked_from_version INTEGER;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS original_author_id UUID REFERENCES auth.users(id) ON DELETE SET NULL;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS original_author_name VARCHAR(255);
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS fork_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_recipes_forked_from ON recipes(forked_from_recipe_id);
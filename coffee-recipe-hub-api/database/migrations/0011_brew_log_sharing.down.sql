@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_brew_logs_shared;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS modifications;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS shared_at;
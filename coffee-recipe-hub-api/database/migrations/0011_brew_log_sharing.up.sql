@@ -0,0 +1,6 @@
+-- 抽出ログをレシピへの「Brewed it!」として公開する（shared_at が NULL なら非公開）
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS shared_at TIMESTAMPTZ;
+-- レシピから変えた点（公開時のみ）
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS modifications TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_brew_logs_shared ON brew_logs(recipe_id, shared_at) WHERE shared_at IS NOT NULL;
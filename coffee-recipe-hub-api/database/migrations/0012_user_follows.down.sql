@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_recipes_user_public;
+DROP TABLE IF EXISTS user_follows;
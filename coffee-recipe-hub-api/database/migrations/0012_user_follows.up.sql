@@ -0,0 +1,18 @@
+-- フォロー関係（follower が followee をフォローする）
+CREATE TABLE IF NOT EXISTS user_follows (
+    follower_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_follows_followee ON user_follows(followee_id, created_at);
+
+-- フィード用：ユーザーごとの公開レシピを新しい順に
+CREATE INDEX IF NOT EXISTS idx_recipes_user_public ON recipes(user_id, created_at) WHERE is_public = true;
+
+ALTER TABLE user_follows ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Anyone can view follows" ON user_follows FOR SELECT USING (true);
+CREATE POLICY "Users can follow as themselves" ON user_follows FOR INSERT WITH CHECK (auth.uid() = follower_id);
+CREATE POLICY "Users can unfollow as themselves" ON user_follows FOR DELETE USING (auth.uid() = follower_id);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS on_auth_user_created ON auth.users;
+DROP FUNCTION IF EXISTS create_profile_for_new_user();
+DROP TABLE IF EXISTS profiles;
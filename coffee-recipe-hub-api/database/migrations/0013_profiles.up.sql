@@ -0,0 +1,44 @@
+-- ユーザーの公開プロフィール（レシピの作者名はここから表示する）
+CREATE TABLE IF NOT EXISTS profiles (
+    id UUID PRIMARY KEY REFERENCES auth.users(id) ON DELETE CASCADE,
+    display_name VARCHAR(100) NOT NULL DEFAULT '',
+    avatar_url TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- recipes.author_name は以前クライアントが送っていた作者名（今後は書き込まない）
+-- 既存ユーザーの表示名はサインアップ時のメタデータ、なければ最後に更新したレシピの作者名を使う
+INSERT INTO profiles (id, display_name, avatar_url, created_at)
+SELECT u.id,
+       LEFT(COALESCE(NULLIF(u.raw_user_meta_data->>'display_name', ''), (
+           SELECT r.author_name FROM recipes r
+           WHERE r.user_id = u.id AND COALESCE(r.author_name, '') <> ''
+           ORDER BY r.updated_at DESC LIMIT 1
+       ), ''), 100),
+       NULLIF(u.raw_user_meta_data->>'avatar_url', ''),
+       COALESCE(u.created_at, NOW())
+FROM auth.users u
+ON CONFLICT (id) DO NOTHING;
+
+-- 新規ユーザーのプロフィールを作成する
+CREATE OR REPLACE FUNCTION create_profile_for_new_user() RETURNS TRIGGER
+    LANGUAGE plpgsql SECURITY DEFINER SET search_path = public
+    AS $$
+BEGIN
+    INSERT INTO profiles (id, display_name, avatar_url)
+    VALUES (NEW.id, LEFT(COALESCE(NEW.raw_user_meta_data->>'display_name', ''), 100),
+            NULLIF(NEW.raw_user_meta_data->>'avatar_url', ''))
+    ON CONFLICT (id) DO NOTHING;
+    RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS on_auth_user_created ON auth.users;
+CREATE TRIGGER on_auth_user_created
+    AFTER INSERT ON auth.users
+    FOR EACH ROW EXECUTE FUNCTION create_profile_for_new_user();
+
+ALTER TABLE profiles ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Anyone can view profiles" ON profiles FOR SELECT USING (true);
+CREATE POLICY "Users can update own profile" ON profiles FOR UPDATE USING (auth.uid() = id);
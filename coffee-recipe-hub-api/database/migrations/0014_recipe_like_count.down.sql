@@ -0,0 +1,2 @@
+ALTER TABLE recipes DROP CONSTRAINT IF EXISTS recipes_like_count_check;
+ALTER TABLE recipes ALTER COLUMN like_count DROP NOT NULL;
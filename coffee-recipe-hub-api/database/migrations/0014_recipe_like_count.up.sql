@@ -0,0 +1,7 @@
+-- いいね・取り消しはレシピの行をロックしたトランザクション内で like_count を更新する
+-- 以前の更新はトランザクション外だったため、ずれた件数を数え直す
+UPDATE recipes
+SET like_count = (SELECT COUNT(*) FROM recipe_likes WHERE recipe_likes.recipe_id = recipes.id);
+
+ALTER TABLE recipes ALTER COLUMN like_count SET NOT NULL;
+ALTER TABLE recipes ADD CONSTRAINT recipes_like_count_check CHECK (like_count >= 0);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_brew_logs_shared_at;
+DROP INDEX IF EXISTS idx_recipes_forked_created_at;
+DROP INDEX IF EXISTS idx_recipe_likes_created_at;
+DROP TABLE IF EXISTS recipe_scores;
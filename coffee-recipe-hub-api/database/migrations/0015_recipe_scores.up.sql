@@ -0,0 +1,17 @@
+-- 公開レシピの人気順のスコア（いいね・フォーク・「Brewed it!」から定期的に集計し直す）
+-- 行がないレシピのスコアは 0 として扱う
+CREATE TABLE IF NOT EXISTS recipe_scores (
+    recipe_id UUID PRIMARY KEY REFERENCES recipes(id) ON DELETE CASCADE,
+    trending_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    top_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 集計対象の期間のイベントを引く
+CREATE INDEX IF NOT EXISTS idx_recipe_likes_created_at ON recipe_likes(created_at);
+CREATE INDEX IF NOT EXISTS idx_recipes_forked_created_at ON recipes(created_at) WHERE forked_from_recipe_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_brew_logs_shared_at ON brew_logs(shared_at) WHERE shared_at IS NOT NULL;
+
+ALTER TABLE recipe_scores ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Scores of public recipes are viewable by everyone" ON recipe_scores FOR SELECT
+    USING (EXISTS (SELECT 1 FROM recipes WHERE recipes.id = recipe_scores.recipe_id AND recipes.is_public = true));
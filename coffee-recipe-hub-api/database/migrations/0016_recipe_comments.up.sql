@@ -0,0 +1,40 @@
+-- レシピへのコメント（parent_id があれば返信。返信への返信は作らない）
+-- 削除は deleted_at を設定するだけで行は残し、返信が残るコメントは tombstone として表示する
+CREATE TABLE IF NOT EXISTS recipe_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recipe_id UUID NOT NULL REFERENCES recipes(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES recipe_comments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID REFERENCES auth.users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_recipe_comments_recipe ON recipe_comments(recipe_id, created_at) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_recipe_comments_parent ON recipe_comments(parent_id, created_at) WHERE parent_id IS NOT NULL;
+
+-- 通報されたコメント（レビュー待ちのキュー。同じユーザーは同じコメントを1回だけ通報できる）
+CREATE TABLE IF NOT EXISTS comment_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID NOT NULL REFERENCES recipe_comments(id) ON DELETE CASCADE,
+    reporter_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'resolved', 'dismissed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (comment_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_reports_pending ON comment_reports(created_at) WHERE status = 'pending';
+
+ALTER TABLE recipe_comments ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Comments on public recipes are viewable by everyone" ON recipe_comments FOR SELECT
+    USING (EXISTS (SELECT 1 FROM recipes WHERE recipes.id = recipe_comments.recipe_id AND recipes.is_public = true));
+CREATE POLICY "Users can comment as themselves" ON recipe_comments FOR INSERT WITH CHECK (auth.uid() = user_id);
+CREATE POLICY "Users can edit own comments" ON recipe_comments FOR UPDATE USING (auth.uid() = user_id);
+
+-- 通報はレビュー担当（API）のみが読む
+ALTER TABLE comment_reports ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Users can report as themselves" ON comment_reports FOR INSERT WITH CHECK (auth.uid() = reporter_id);
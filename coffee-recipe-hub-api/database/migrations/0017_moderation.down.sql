@@ -0,0 +1,36 @@
+DROP POLICY IF EXISTS "Anyone can view profiles" ON profiles;
+CREATE POLICY "Anyone can view profiles" ON profiles FOR SELECT USING (true);
+
+DROP POLICY IF EXISTS "Comments on public recipes are viewable by everyone" ON recipe_comments;
+CREATE POLICY "Comments on public recipes are viewable by everyone" ON recipe_comments FOR SELECT
+    USING (EXISTS (SELECT 1 FROM recipes WHERE recipes.id = recipe_comments.recipe_id AND recipes.is_public = true));
+
+DROP POLICY IF EXISTS "Users can view own and public recipes" ON recipes;
+CREATE POLICY "Users can view own and public recipes" ON recipes FOR SELECT USING (auth.uid() = user_id OR is_public = TRUE);
+
+ALTER TABLE profiles DROP COLUMN IF EXISTS banned_at;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS hidden_reason;
+ALTER TABLE brew_logs DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE recipe_comments DROP COLUMN IF EXISTS hidden_reason;
+ALTER TABLE recipe_comments DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE recipes DROP COLUMN IF EXISTS hidden_reason;
+ALTER TABLE recipes DROP COLUMN IF EXISTS hidden_at;
+
+CREATE TABLE IF NOT EXISTS comment_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    comment_id UUID NOT NULL REFERENCES recipe_comments(id) ON DELETE CASCADE,
+    reporter_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'resolved', 'dismissed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (comment_id, reporter_id)
+);
+CREATE INDEX IF NOT EXISTS idx_comment_reports_pending ON comment_reports(created_at) WHERE status = 'pending';
+ALTER TABLE comment_reports ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Users can report as themselves" ON comment_reports FOR INSERT WITH CHECK (auth.uid() = reporter_id);
+
+INSERT INTO comment_reports (comment_id, reporter_id, reason, status, created_at)
+SELECT target_id, reporter_id, reason, status, created_at FROM reports r
+WHERE target_type = 'comment' AND EXISTS (SELECT 1 FROM recipe_comments c WHERE c.id = r.target_id)
+ON CONFLICT DO NOTHING;
+DROP TABLE IF EXISTS reports;
@@ -0,0 +1,52 @@
+-- 通報（レシピ・コメント・ユーザー）。comment_reports を統合する
+-- 対象は種類ごとに別テーブルのため target_id に外部キーは張らない
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('recipe', 'comment', 'user')),
+    target_id UUID NOT NULL,
+    reporter_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'resolved', 'dismissed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    resolved_by UUID REFERENCES auth.users(id) ON DELETE SET NULL,
+    UNIQUE (target_type, target_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_status ON reports(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports(target_type, target_id);
+
+INSERT INTO reports (target_type, target_id, reporter_id, reason, status, created_at)
+SELECT 'comment', comment_id, reporter_id, reason, status, created_at FROM comment_reports
+ON CONFLICT DO NOTHING;
+DROP TABLE IF EXISTS comment_reports;
+
+-- 通報はレビュー担当（API）のみが読む
+ALTER TABLE reports ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Users can report as themselves" ON reports FOR INSERT WITH CHECK (auth.uid() = reporter_id);
+
+-- 管理者による非表示（hidden_reason: moderation は個別の非表示、ban は利用停止に伴う非表示）
+-- 非表示のコンテンツは作者本人以外には返さない
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+ALTER TABLE recipes ADD COLUMN IF NOT EXISTS hidden_reason VARCHAR(20) CHECK (hidden_reason IN ('moderation', 'ban'));
+ALTER TABLE recipe_comments ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+ALTER TABLE recipe_comments ADD COLUMN IF NOT EXISTS hidden_reason VARCHAR(20) CHECK (hidden_reason IN ('moderation', 'ban'));
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+ALTER TABLE brew_logs ADD COLUMN IF NOT EXISTS hidden_reason VARCHAR(20) CHECK (hidden_reason IN ('moderation', 'ban'));
+
+-- 利用停止中のユーザーは書き込みできず、プロフィールも公開しない
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS banned_at TIMESTAMPTZ;
+
+DROP POLICY IF EXISTS "Users can view own and public recipes" ON recipes;
+CREATE POLICY "Users can view own and public recipes" ON recipes FOR SELECT
+    USING (auth.uid() = user_id OR (is_public = TRUE AND hidden_at IS NULL));
+
+DROP POLICY IF EXISTS "Comments on public recipes are viewable by everyone" ON recipe_comments;
+CREATE POLICY "Comments on public recipes are viewable by everyone" ON recipe_comments FOR SELECT
+    USING (hidden_at IS NULL AND EXISTS (
+        SELECT 1 FROM recipes
+        WHERE recipes.id = recipe_comments.recipe_id AND recipes.is_public = true AND recipes.hidden_at IS NULL
+    ));
+
+DROP POLICY IF EXISTS "Anyone can view profiles" ON profiles;
+CREATE POLICY "Anyone can view profiles" ON profiles FOR SELECT USING (banned_at IS NULL OR auth.uid() = id);
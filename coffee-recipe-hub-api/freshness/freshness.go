@@ -0,0 +1,168 @@
+// Package freshness 焙煎日からの経過日数で豆の飲み頃を判定する
+package freshness
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+// Window 焙煎日からの日数で表した鮮度の区切り
+//
+//	0 .. RestDays-1            resting（エイジング中）
+//	RestDays .. PeakEndDays-1  peak（飲み頃）
+//	PeakEndDays .. StaleDays-1 fading（飲み頃を過ぎつつある）
+//	StaleDays ..               stale
+type Window struct {
+	RestDays    int `json:"restDays"`
+	PeakEndDays int `json:"peakEndDays"`
+	StaleDays   int `json:"staleDays"`
+}
+
+// Valid 区切りが昇順になっているか
+func (w Window) Valid() bool {
+	return w.RestDays >= 0 && w.RestDays <= w.PeakEndDays && w.PeakEndDays <= w.StaleDays
+}
+
+// StateAt 焙煎からの経過日数に対する状態
+func (w Window) StateAt(days int) models.FreshnessState {
+	switch {
+	case days < w.RestDays:
+		return models.FreshnessResting
+	case days < w.PeakEndDays:
+		return models.FreshnessPeak
+	case days < w.StaleDays:
+		return models.FreshnessFading
+	default:
+		return models.FreshnessStale
+	}
+}
+
+// defaultWindows 焙煎度ごとの既定値（浅煎りほどガスが抜けるまで長く休ませる）
+var defaultWindows = map[models.RoastLevel]Window{
+	models.RoastLevelLight:       {RestDays: 10, PeakEndDays: 35, StaleDays: 60},
+	models.RoastLevelMediumLight: {RestDays: 7, PeakEndDays: 30, StaleDays: 50},
+	models.RoastLevelMedium:      {RestDays: 5, PeakEndDays: 25, StaleDays: 45},
+	models.RoastLevelMediumDark:  {RestDays: 4, PeakEndDays: 21, StaleDays: 40},
+	models.RoastLevelDark:        {RestDays: 3, PeakEndDays: 18, StaleDays: 35},
+}
+
+// fallbackWindow 焙煎度が未設定の豆に使う
+var fallbackWindow = Window{RestDays: 5, PeakEndDays: 25, StaleDays: 45}
+
+// Model 焙煎度ごとの鮮度の区切り
+type Model struct {
+	windows  map[models.RoastLevel]Window
+	fallback Window
+}
+
+// NewModel 既定値のモデルを作成
+func NewModel() *Model {
+	windows := make(map[models.RoastLevel]Window, len(defaultWindows))
+	for level, w := range defaultWindows {
+		windows[level] = w
+	}
+	return &Model{windows: windows, fallback: fallbackWindow}
+}
+
+// ModelFromEnv 環境変数で焙煎度ごとの区切りを上書きしたモデルを作成
+// FRESHNESS_LIGHT=10,35,60 のように rest,peakEnd,stale の日数を指定する（不正な値は警告して既定値を使う）
+func ModelFromEnv() *Model {
+	m := NewModel()
+	for level := range m.windows {
+		name := "FRESHNESS_" + string(level)
+		s := os.Getenv(name)
+		if s == "" {
+			continue
+		}
+		w, err := parseWindow(s)
+		if err != nil {
+			log.Printf("⚠️  WARNING: ignoring %s: %v", name, err)
+			continue
+		}
+		m.windows[level] = w
+	}
+	return m
+}
+
+func parseWindow(s string) (Window, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return Window{}, fmt.Errorf("want rest,peakEnd,stale days, got %q", s)
+	}
+	var days [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return Window{}, fmt.Errorf("invalid number %q", p)
+		}
+		days[i] = n
+	}
+	w := Window{RestDays: days[0], PeakEndDays: days[1], StaleDays: days[2]}
+	if !w.Valid() {
+		return Window{}, fmt.Errorf("days must satisfy 0 <= rest <= peakEnd <= stale, got %q", s)
+	}
+	return w, nil
+}
+
+// Default 焙煎度の既定の区切り
+func (m *Model) Default(level models.RoastLevel) Window {
+	if w, ok := m.windows[level]; ok {
+		return w
+	}
+	return m.fallback
+}
+
+// WindowFor 豆ごとの上書きを反映した区切り
+func (m *Model) WindowFor(bean *models.Bean) Window {
+	return m.resolve(bean.RoastLevel, bean.RestDays, bean.PeakEndDays, bean.StaleDays)
+}
+
+// resolve 焙煎度の既定値に指定された日数を重ねる
+func (m *Model) resolve(level models.RoastLevel, rest, peakEnd, stale *int) Window {
+	w := m.Default(level)
+	if rest != nil {
+		w.RestDays = *rest
+	}
+	if peakEnd != nil {
+		w.PeakEndDays = *peakEnd
+	}
+	if stale != nil {
+		w.StaleDays = *stale
+	}
+	return w
+}
+
+// ValidOverrides 豆の上書きを既定値に重ねた結果が昇順になっているか
+func (m *Model) ValidOverrides(level models.RoastLevel, rest, peakEnd, stale *int) bool {
+	return m.resolve(level, rest, peakEnd, stale).Valid()
+}
+
+// DaysSinceRoast 焙煎日（YYYY-MM-DD）から now の日付までの日数（焙煎日が不明なら false）
+// 日付はUTCで数える
+func DaysSinceRoast(roastDate string, now time.Time) (int, bool) {
+	roasted, err := time.Parse("2006-01-02", roastDate)
+	if err != nil {
+		return 0, false
+	}
+	y, mo, d := now.UTC().Date()
+	today := time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+	return int(today.Sub(roasted).Hours() / 24), true
+}
+
+// Annotate 豆のレスポンスに経過日数と鮮度の状態を設定する
+func (m *Model) Annotate(bean *models.Bean, now time.Time) {
+	days, ok := DaysSinceRoast(bean.RoastDate, now)
+	if !ok {
+		bean.DaysSinceRoast = nil
+		bean.FreshnessState = ""
+		return
+	}
+	bean.DaysSinceRoast = &days
+	bean.FreshnessState = m.WindowFor(bean).StateAt(days)
+}
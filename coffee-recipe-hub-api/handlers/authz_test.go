@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const missingID = "00000000-0000-0000-0000-00000000ffff"
+
+// resourceNames 所有者チェックの対象（サブテストごとに新しいサーバーで作り直す）
+var resourceNames = []string{"bean", "recipe", "brew log"}
+
+// ownedResources alice の豆・非公開レシピ・抽出ログを作成し、種類ごとのパスと更新リクエストを返す
+func ownedResources(s *testServer) map[string]struct {
+	path   string
+	update gin.H
+} {
+	owner := s.as(alice)
+	beanID := owner.createBean()
+	recipeID := owner.createRecipe(false)
+	logID := owner.createBrewLog(recipeID, beanID)
+
+	return map[string]struct {
+		path   string
+		update gin.H
+	}{
+		"bean":     {"/beans/" + beanID, gin.H{"name": "Renamed"}},
+		"recipe":   {"/recipes/" + recipeID, recipeBody("Renamed", false)},
+		"brew log": {"/brew-logs/" + logID, gin.H{"recipeId": recipeID, "beanId": beanID, "rating": 5}},
+	}
+}
+
+func TestOwnerCanAccessOwnResources(t *testing.T) {
+	for _, name := range resourceNames {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t)
+			res := ownedResources(s)[name]
+			owner := s.as(alice)
+			expect(t, owner.do(http.MethodGet, res.path, nil), http.StatusOK)
+			expect(t, owner.do(http.MethodPut, res.path, res.update), http.StatusOK)
+			expect(t, owner.do(http.MethodDelete, res.path, nil), http.StatusOK)
+			expect(t, owner.do(http.MethodGet, res.path, nil), http.StatusNotFound)
+		})
+	}
+}
+
+func TestNonOwnerIsForbidden(t *testing.T) {
+	for _, name := range resourceNames {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t)
+			res := ownedResources(s)[name]
+			other := s.as(bob)
+			expect(t, other.do(http.MethodGet, res.path, nil), http.StatusForbidden)
+			expect(t, other.do(http.MethodPut, res.path, res.update), http.StatusForbidden)
+			expect(t, other.do(http.MethodDelete, res.path, nil), http.StatusForbidden)
+			// 拒否されたリクエストで変更・削除されていない
+			expect(t, s.as(alice).do(http.MethodGet, res.path, nil), http.StatusOK)
+		})
+	}
+}
+
+func TestUnauthenticatedIsRejected(t *testing.T) {
+	for _, name := range resourceNames {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t)
+			res := ownedResources(s)[name]
+			anon := s.anonymous()
+			expect(t, anon.do(http.MethodGet, res.path, nil), http.StatusUnauthorized)
+			expect(t, anon.do(http.MethodPut, res.path, res.update), http.StatusUnauthorized)
+			expect(t, anon.do(http.MethodDelete, res.path, nil), http.StatusUnauthorized)
+		})
+	}
+}
+
+func TestMissingResourceIsNotFound(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	beanID := user.createBean()
+	recipeID := user.createRecipe(false)
+
+	for name, res := range map[string]struct {
+		path   string
+		update gin.H
+	}{
+		"bean":     {"/beans/" + missingID, gin.H{"name": "Renamed"}},
+		"recipe":   {"/recipes/" + missingID, recipeBody("Renamed", false)},
+		"brew log": {"/brew-logs/" + missingID, gin.H{"recipeId": recipeID, "beanId": beanID, "rating": 5}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			expect(t, user.do(http.MethodGet, res.path, nil), http.StatusNotFound)
+			expect(t, user.do(http.MethodPut, res.path, res.update), http.StatusNotFound)
+			expect(t, user.do(http.MethodDelete, res.path, nil), http.StatusNotFound)
+		})
+	}
+}
+
+func TestPublicRecipeIsReadableButNotWritableByOthers(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+	path := "/recipes/" + recipeID
+
+	expect(t, s.anonymous().do(http.MethodGet, path, nil), http.StatusOK)
+	expect(t, s.as(bob).do(http.MethodGet, path, nil), http.StatusOK)
+	expect(t, s.as(bob).do(http.MethodPut, path, recipeBody("Hijacked", true)), http.StatusForbidden)
+	expect(t, s.as(bob).do(http.MethodDelete, path, nil), http.StatusForbidden)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+// banCacheTTL 利用停止状態をキャッシュする時間
+// このプロセスでの利用停止・解除はすぐに反映し、他のインスタンスでの変更は最大でこの時間だけ遅れて反映される
+const banCacheTTL = 30 * time.Second
+
+// banCacheMaxEntries これを超えたら期限切れのエントリーを掃除する
+const banCacheMaxEntries = 10000
+
+// banCache 書き込みのたびに利用停止状態を DB に問い合わせないためのキャッシュ
+type banCache struct {
+	mu      sync.Mutex
+	entries map[string]banEntry
+	gen     uint64 // forget のたびに増やす
+}
+
+type banEntry struct {
+	banned  bool
+	expires time.Time
+}
+
+func newBanCache() *banCache {
+	return &banCache{entries: make(map[string]banEntry)}
+}
+
+// get キャッシュ済みで期限内なら利用停止状態を返す
+func (bc *banCache) get(userID string, now time.Time) (banned, ok bool) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	entry, ok := bc.entries[userID]
+	if !ok || !now.Before(entry.expires) {
+		return false, false
+	}
+	return entry.banned, true
+}
+
+// generation DB に問い合わせる前に取得して set に渡す
+func (bc *banCache) generation() uint64 {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	return bc.gen
+}
+
+// set 問い合わせ結果を保存する（問い合わせ中に利用停止・解除があった古い結果は保存しない）
+func (bc *banCache) set(userID string, banned bool, gen uint64, now time.Time) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if gen != bc.gen {
+		return
+	}
+	if len(bc.entries) >= banCacheMaxEntries {
+		for id, entry := range bc.entries {
+			if !now.Before(entry.expires) {
+				delete(bc.entries, id)
+			}
+		}
+		if len(bc.entries) >= banCacheMaxEntries {
+			clear(bc.entries)
+		}
+	}
+	bc.entries[userID] = banEntry{banned: banned, expires: now.Add(banCacheTTL)}
+}
+
+// forget 利用停止・解除したユーザーのキャッシュを捨てる
+func (bc *banCache) forget(userID string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	delete(bc.entries, userID)
+	bc.gen++
+}
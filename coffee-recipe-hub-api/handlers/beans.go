@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/coffee-recipe-hub/api/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Bean Handlers ==========
+
+// GetBeans 豆一覧取得
+// クエリ: limit, cursor, sort, order, roastLevel, origin, from, to（焙煎日）
+func (h *Handler) GetBeans(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	opts, ok := parseListOptions(c, repository.BeanSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Beans.ListByUser(c.Request.Context(), userID, repository.BeanFilter{
+		ListOptions: opts,
+		RoastLevel:  models.RoastLevel(c.Query("roastLevel")),
+		Origin:      c.Query("origin"),
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	h.annotateBeans(page.Items)
+	c.JSON(http.StatusOK, page)
+}
+
+// GetReadyBeans 飲み頃（peak）の豆一覧取得
+// 飲み頃が早く終わる順に並べる
+func (h *Handler) GetReadyBeans(c *gin.Context) {
+	beans, err := h.allBeans(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	h.annotateBeans(beans)
+
+	ready := []models.Bean{}
+	for _, bean := range beans {
+		if bean.FreshnessState == models.FreshnessPeak {
+			ready = append(ready, bean)
+		}
+	}
+	slices.SortStableFunc(ready, func(a, b models.Bean) int {
+		return cmp.Compare(h.peakDaysLeft(&a), h.peakDaysLeft(&b))
+	})
+	c.JSON(http.StatusOK, gin.H{"items": ready})
+}
+
+// allBeans ユーザーの豆をすべて取得（焙煎日順）
+func (h *Handler) allBeans(ctx context.Context, userID string) ([]models.Bean, error) {
+	var beans []models.Bean
+	f := repository.BeanFilter{ListOptions: repository.ListOptions{Limit: repository.MaxLimit, Sort: "roastDate"}}
+	for {
+		page, err := h.store.Beans.ListByUser(ctx, userID, f)
+		if err != nil {
+			return nil, err
+		}
+		beans = append(beans, page.Items...)
+		if page.NextCursor == "" {
+			return beans, nil
+		}
+		f.Cursor = page.NextCursor
+	}
+}
+
+// peakDaysLeft 飲み頃が終わるまでの日数
+func (h *Handler) peakDaysLeft(bean *models.Bean) int {
+	return h.freshness.WindowFor(bean).PeakEndDays - *bean.DaysSinceRoast
+}
+
+// annotateBeans 経過日数と鮮度の状態を設定する
+func (h *Handler) annotateBeans(beans []models.Bean) {
+	now := time.Now()
+	for i := range beans {
+		h.freshness.Annotate(&beans[i], now)
+	}
+}
+
+// validFreshnessOverrides 飲み頃の区切りの上書きが焙煎度の既定値と矛盾しないか検証する
+func (h *Handler) validFreshnessOverrides(c *gin.Context, level models.RoastLevel, rest, peakEnd, stale *int) bool {
+	if h.freshness.ValidOverrides(level, rest, peakEnd, stale) {
+		return true
+	}
+	d := h.freshness.Default(level)
+	respondValidation(c, validation.Errors{{
+		Field: "restDays",
+		Code:  validation.CodeInvalidOrder,
+		Message: fmt.Sprintf("restDays, peakEndDays and staleDays must be in ascending order (defaults for %s: %d, %d, %d)",
+			level, d.RestDays, d.PeakEndDays, d.StaleDays),
+	}})
+	return false
+}
+
+// GetBean 豆詳細取得
+func (h *Handler) GetBean(c *gin.Context) {
+	bean, err := h.store.Beans.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBean, bean.UserID) {
+		return
+	}
+	h.freshness.Annotate(bean, time.Now())
+	c.JSON(http.StatusOK, bean)
+}
+
+// CreateBean 豆作成
+func (h *Handler) CreateBean(c *gin.Context) {
+	var req models.CreateBeanRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !h.validFreshnessOverrides(c, req.RoastLevel, req.RestDays, req.PeakEndDays, req.StaleDays) {
+		return
+	}
+
+	userID := c.GetString("userID")
+
+	bean, err := h.store.Beans.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		respondInternalError(c, err)
+		return
+	}
+	h.freshness.Annotate(bean, time.Now())
+	c.JSON(http.StatusCreated, bean)
+}
+
+// UpdateBean 豆更新
+// stockGrams は現在の在庫との差分を adjustment として台帳に記録する
+func (h *Handler) UpdateBean(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	bean, err := h.store.Beans.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBean, bean.UserID) {
+		return
+	}
+
+	var req models.UpdateBeanRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !h.validFreshnessOverrides(c, req.RoastLevel, req.RestDays, req.PeakEndDays, req.StaleDays) {
+		return
+	}
+
+	if err := h.store.Beans.Update(ctx, id, c.GetString("userID"), req); err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Bean updated"})
+}
+
+// DeleteBean 豆削除
+func (h *Handler) DeleteBean(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	bean, err := h.store.Beans.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBean, bean.UserID) {
+		return
+	}
+
+	if err := h.store.Beans.Delete(ctx, id, c.GetString("userID")); err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Bean deleted"})
+}
+
+// GetBeanStockHistory 豆の在庫台帳取得
+// クエリ: limit, cursor, order
+func (h *Handler) GetBeanStockHistory(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	bean, err := h.store.Beans.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBean, bean.UserID) {
+		return
+	}
+
+	opts, ok := parseListOptions(c, repository.StockMovementSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Beans.StockHistory(ctx, id, opts)
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// CreateStockMovement 在庫の購入・廃棄・調整を記録
+func (h *Handler) CreateStockMovement(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	bean, err := h.store.Beans.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBean, bean.UserID) {
+		return
+	}
+
+	var req models.CreateStockMovementRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	grams := req.Grams
+	switch req.Type {
+	case models.StockMovementPurchase, models.StockMovementWaste:
+		if grams < 0 {
+			respondValidation(c, validation.Errors{{
+				Field: "grams", Code: validation.CodeOutOfRange, Message: "must be positive for " + string(req.Type),
+			}})
+			return
+		}
+		if req.Type == models.StockMovementWaste {
+			grams = -grams
+		}
+	}
+
+	movement, err := h.store.Beans.RecordMovement(ctx, id, c.GetString("userID"), req.Type, grams, req.Note)
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return
+	}
+	c.JSON(http.StatusCreated, movement)
+}
@@ -0,0 +1,27 @@
+package handlers_test
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBeanRoastDateMustBeISODate(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	beanID := user.createBean()
+
+	for _, date := range []string{"2024/05/01", "2024-13-01", "yesterday"} {
+		t.Run(date, func(t *testing.T) {
+			bean := gin.H{"name": "Kenya AA", "roastLevel": "LIGHT", "roastDate": date}
+			if fields := invalidFields(t, user.do(http.MethodPost, "/beans", bean)); !slices.Equal(fields, []string{"roastDate"}) {
+				t.Errorf("invalid fields = %v, want [roastDate]", fields)
+			}
+			expect(t, user.do(http.MethodPut, "/beans/"+beanID, bean), http.StatusBadRequest)
+		})
+	}
+
+	created(t, user.do(http.MethodPost, "/beans", gin.H{"name": "Kenya AA", "roastDate": "2024-05-01"}), http.StatusCreated)
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== BrewLog Handlers ==========
+
+// GetBrewLogs 抽出ログ一覧取得
+// クエリ: limit, cursor, sort, order, recipeId, beanId, minRating, maxRating, from, to（抽出日）
+func (h *Handler) GetBrewLogs(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	opts, ok := parseListOptions(c, repository.BrewLogSortKeys, "brewDate")
+	if !ok {
+		return
+	}
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	minRating, ok := parseIntQuery(c, "minRating", 1, 5)
+	if !ok {
+		return
+	}
+	maxRating, ok := parseIntQuery(c, "maxRating", 1, 5)
+	if !ok {
+		return
+	}
+
+	page, err := h.store.BrewLogs.ListByUser(c.Request.Context(), userID, repository.BrewLogFilter{
+		ListOptions: opts,
+		RecipeID:    c.Query("recipeId"),
+		BeanID:      c.Query("beanId"),
+		MinRating:   minRating,
+		MaxRating:   maxRating,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// GetBrewLog 抽出ログ詳細取得
+func (h *Handler) GetBrewLog(c *gin.Context) {
+	log, err := h.store.BrewLogs.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBrewLog, log.UserID) {
+		return
+	}
+	c.JSON(http.StatusOK, log)
+}
+
+// authorizeBrewLogRefs ログが参照するレシピ・豆を使えるか検証し、省略された抽出条件をレシピの値で補完する
+// 他人の豆の在庫を減らしたり、非公開レシピを参照できないようにする
+// 版が省略されていればレシピの現在の版に固定し、補完にはその版の値を使う
+func (h *Handler) authorizeBrewLogRefs(c *gin.Context, req *models.CreateBrewLogRequest) bool {
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, req.RecipeID)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return false
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return false
+	}
+	if req.RecipeVersion == 0 {
+		req.RecipeVersion = recipe.Version
+	}
+	rev, err := h.store.Recipes.GetVersion(ctx, recipe.ID, req.RecipeVersion)
+	if err != nil {
+		respondError(c, resourceRecipeVersion, err)
+		return false
+	}
+	bean, err := h.store.Beans.Get(ctx, req.BeanID)
+	if err != nil {
+		respondError(c, resourceBean, err)
+		return false
+	}
+	if !authorizeOwner(c, resourceBean, bean.UserID) {
+		return false
+	}
+
+	applyRecipeDefaults(req, rev)
+	return true
+}
+
+// applyRecipeDefaults 省略された抽出条件（豆量・湯量・湯温・挽き目）をレシピの版の値で補完する
+func applyRecipeDefaults(req *models.CreateBrewLogRequest, recipe *models.RecipeRevision) {
+	if req.CoffeeGrams == 0 {
+		req.CoffeeGrams = recipe.CoffeeGrams
+	}
+	if req.WaterMl == 0 {
+		req.WaterMl = recipe.TotalWaterMl
+	}
+	if req.WaterTemperature == 0 {
+		req.WaterTemperature = recipe.WaterTemperature
+	}
+	if req.GrindSetting == "" {
+		req.GrindSetting = string(recipe.GrindSize)
+	}
+}
+
+// CreateBrewLog 抽出ログ作成（実際の豆量を在庫から差し引く）
+func (h *Handler) CreateBrewLog(c *gin.Context) {
+	var req models.CreateBrewLogRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID := c.GetString("userID")
+
+	if !h.authorizeBrewLogRefs(c, &req) {
+		return
+	}
+
+	log, err := h.store.BrewLogs.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		respondInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, log)
+}
+
+// UpdateBrewLog 抽出ログ更新（豆・豆量の変更時は在庫を付け替える）
+// レシピを変えると「Brewed it!」の公開は取り消される
+func (h *Handler) UpdateBrewLog(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	current, err := h.store.BrewLogs.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBrewLog, current.UserID) {
+		return
+	}
+
+	var req models.CreateBrewLogRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	// 同じレシピのままなら記録済みの版を保つ
+	if req.RecipeVersion == 0 && req.RecipeID == current.RecipeID {
+		req.RecipeVersion = current.RecipeVersion
+	}
+	if !h.authorizeBrewLogRefs(c, &req) {
+		return
+	}
+
+	log, err := h.store.BrewLogs.Update(ctx, id, c.GetString("userID"), req)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	c.JSON(http.StatusOK, log)
+}
+
+// DeleteBrewLog 抽出ログ削除（差し引いた在庫を戻す）
+func (h *Handler) DeleteBrewLog(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	log, err := h.store.BrewLogs.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBrewLog, log.UserID) {
+		return
+	}
+
+	if err := h.store.BrewLogs.Delete(ctx, id, c.GetString("userID")); err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Brew log deleted"})
+}
@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestChangingRecipeUnsharesBrewLog(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	beanID := user.createBean()
+	sharedID := user.createRecipe(true)
+	otherID := user.createRecipe(true)
+	logID := user.createBrewLog(sharedID, beanID)
+	path := "/brew-logs/" + logID
+
+	expect(t, user.do(http.MethodPost, path+"/share", gin.H{"modifications": "Finer grind"}), http.StatusOK)
+
+	// 同じレシピのままなら公開を保つ
+	expect(t, user.do(http.MethodPut, path, gin.H{"recipeId": sharedID, "beanId": beanID, "rating": 5}), http.StatusOK)
+	if n := brewCount(t, s, sharedID); n != 1 {
+		t.Fatalf("brews on the shared recipe = %d, want 1", n)
+	}
+
+	rec := user.do(http.MethodPut, path, gin.H{"recipeId": otherID, "beanId": beanID, "rating": 5})
+	expect(t, rec, http.StatusOK)
+	var log models.BrewLog
+	decode(t, rec, &log)
+	if log.SharedAt != nil || log.Modifications != "" {
+		t.Errorf("brew log after changing recipe = %+v, want it unshared", log)
+	}
+	if n := brewCount(t, s, sharedID); n != 0 {
+		t.Errorf("brews on the previous recipe = %d, want 0", n)
+	}
+	if n := brewCount(t, s, otherID); n != 0 {
+		t.Errorf("brews on the new recipe = %d, want 0 until shared again", n)
+	}
+}
+
+// brewCount レシピに公開された「Brewed it!」の件数
+func brewCount(t *testing.T, s *testServer, recipeID string) int {
+	t.Helper()
+	rec := s.anonymous().do(http.MethodGet, "/recipes/"+recipeID+"/brews", nil)
+	expect(t, rec, http.StatusOK)
+	var page struct {
+		Items []models.BrewPost `json:"items"`
+	}
+	decode(t, rec, &page)
+	return len(page.Items)
+}
+
+func TestTasteNoteScoresAreValidated(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	beanID := user.createBean()
+	recipeID := user.createRecipe(false)
+	logID := user.createBrewLog(recipeID, beanID)
+
+	for _, score := range []int{0, 6} {
+		body := gin.H{"recipeId": recipeID, "beanId": beanID, "rating": 4, "tasteNotes": []gin.H{
+			{"aspect": "acidity", "score": 3},
+			{"aspect": "body", "score": score},
+		}}
+		want := []string{"tasteNotes[1].score"}
+		if fields := invalidFields(t, user.do(http.MethodPost, "/brew-logs", body)); !slices.Equal(fields, want) {
+			t.Errorf("score %d: invalid fields on create = %v, want %v", score, fields, want)
+		}
+		if fields := invalidFields(t, user.do(http.MethodPut, "/brew-logs/"+logID, body)); !slices.Equal(fields, want) {
+			t.Errorf("score %d: invalid fields on update = %v, want %v", score, fields, want)
+		}
+	}
+}
+
+func TestBrewLogDurationAndMemoAreValidated(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	beanID := user.createBean()
+	recipeID := user.createRecipe(false)
+	logID := user.createBrewLog(recipeID, beanID)
+
+	body := gin.H{"recipeId": recipeID, "beanId": beanID, "rating": 4, "actualDuration": -1, "memo": strings.Repeat("a", 2001)}
+	want := []string{"actualDuration", "memo"}
+	if fields := invalidFields(t, user.do(http.MethodPost, "/brew-logs", body)); !slices.Equal(fields, want) {
+		t.Errorf("invalid fields on create = %v, want %v", fields, want)
+	}
+	if fields := invalidFields(t, user.do(http.MethodPut, "/brew-logs/"+logID, body)); !slices.Equal(fields, want) {
+		t.Errorf("invalid fields on update = %v, want %v", fields, want)
+	}
+}
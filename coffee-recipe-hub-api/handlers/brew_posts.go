@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Brewed it! Handlers ==========
+
+// recipeBrewsResponse レシピに公開された抽出ログの一覧と集計
+type recipeBrewsResponse struct {
+	repository.Page[models.BrewPost]
+	Summary *models.BrewSummary `json:"summary"`
+}
+
+// ShareBrewLog 抽出ログをレシピへの「Brewed it!」として公開する
+// 公開レシピを使ったログのみ公開できる。豆・在庫は公開しない
+func (h *Handler) ShareBrewLog(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	log, err := h.store.BrewLogs.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBrewLog, log.UserID) {
+		return
+	}
+
+	var req models.ShareBrewLogRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	if log.RecipeID == "" {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Brew log has no recipe to share to"})
+		return
+	}
+	recipe, err := h.store.Recipes.Get(ctx, log.RecipeID)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !recipe.IsPublic || recipe.Hidden {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Only brew logs of public recipes can be shared"})
+		return
+	}
+
+	shared, err := h.store.BrewLogs.Share(ctx, id, c.GetString("userID"), req)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	c.JSON(http.StatusOK, shared)
+}
+
+// UnshareBrewLog 抽出ログの公開をやめる
+func (h *Handler) UnshareBrewLog(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	log, err := h.store.BrewLogs.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	if !authorizeOwner(c, resourceBrewLog, log.UserID) {
+		return
+	}
+
+	unshared, err := h.store.BrewLogs.Unshare(ctx, id, c.GetString("userID"))
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	c.JSON(http.StatusOK, unshared)
+}
+
+// GetRecipeBrews レシピに公開された抽出ログ一覧と評価・味の平均取得
+// クエリ: limit, cursor, sort（sharedAt / rating）, order
+func (h *Handler) GetRecipeBrews(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	opts, ok := parseListOptions(c, repository.BrewPostSortKeys, "sharedAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.BrewLogs.ListShared(ctx, recipe.ID, opts)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	summary, err := h.store.BrewLogs.SharedSummary(ctx, recipe.ID)
+	if err != nil {
+		respondError(c, resourceBrewLog, err)
+		return
+	}
+	c.JSON(http.StatusOK, recipeBrewsResponse{Page: page, Summary: summary})
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Comment Handlers ==========
+
+// GetRecipeComments レシピのコメント一覧取得（各コメントに返信を古い順に含める）
+// 削除されたコメントは返信が残っている場合のみ、本文と投稿者を除いた tombstone として返す
+// クエリ: limit, cursor, order（投稿日時順）
+func (h *Handler) GetRecipeComments(c *gin.Context) {
+	opts, ok := parseListOptions(c, repository.CommentSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	recipe, err := h.store.Recipes.Get(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	page, err := h.store.Comments.List(ctx, recipe.ID, opts)
+	if err != nil {
+		respondError(c, resourceComment, err)
+		return
+	}
+	for i := range page.Items {
+		tombstone(&page.Items[i])
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// tombstone 削除済みのコメントから本文と投稿者を除く
+func tombstone(comment *models.Comment) {
+	if !comment.Deleted {
+		return
+	}
+	comment.UserID = ""
+	comment.AuthorName = ""
+	comment.Body = ""
+	comment.EditedAt = nil
+}
+
+// CreateRecipeComment 公開レシピにコメント（parentId を指定するとそのコメントへの返信）
+// 非表示のレシピには作者本人もコメントできない
+func (h *Handler) CreateRecipeComment(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req models.CreateCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	recipe, err := h.store.Recipes.Get(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !recipe.IsPublic || recipe.Hidden {
+		respondError(c, resourceRecipe, repository.ErrNotFound)
+		return
+	}
+
+	if req.ParentID != "" {
+		parent, err := h.store.Comments.Get(ctx, req.ParentID)
+		if err == nil && parent.RecipeID != recipe.ID {
+			err = repository.ErrNotFound
+		}
+		if err != nil {
+			respondError(c, resourceComment, err)
+			return
+		}
+		if parent.ParentID != "" {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Cannot reply to a reply"})
+			return
+		}
+		if parent.Deleted {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Cannot reply to a deleted comment"})
+			return
+		}
+	}
+
+	comment, err := h.store.Comments.Create(ctx, recipe.ID, userID, req)
+	if err != nil {
+		respondError(c, resourceComment, err)
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// getRecipeComment URLのレシピに属する削除されていないコメントを取得する（なければ404を返してnil）
+func (h *Handler) getRecipeComment(c *gin.Context) *models.Comment {
+	comment, err := h.store.Comments.Get(c.Request.Context(), c.Param("commentId"))
+	if err == nil && (comment.RecipeID != c.Param("id") || comment.Deleted) {
+		err = repository.ErrNotFound
+	}
+	if err != nil {
+		respondError(c, resourceComment, err)
+		return nil
+	}
+	return comment
+}
+
+// UpdateRecipeComment コメントを編集（投稿者のみ）
+func (h *Handler) UpdateRecipeComment(c *gin.Context) {
+	comment := h.getRecipeComment(c)
+	if comment == nil {
+		return
+	}
+	if !authorizeOwner(c, resourceComment, comment.UserID) {
+		return
+	}
+
+	var req models.UpdateCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	updated, err := h.store.Comments.Update(c.Request.Context(), comment.ID, c.GetString("userID"), req)
+	if err != nil {
+		respondError(c, resourceComment, err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteRecipeComment コメントを削除（投稿者かレシピの作者のみ）
+// 返信が残っているコメントは一覧に tombstone として残る
+func (h *Handler) DeleteRecipeComment(c *gin.Context) {
+	comment := h.getRecipeComment(c)
+	if comment == nil {
+		return
+	}
+
+	ctx := c.Request.Context()
+	userID := c.GetString("userID")
+	if userID != comment.UserID {
+		recipe, err := h.store.Recipes.Get(ctx, comment.RecipeID)
+		if err != nil {
+			respondError(c, resourceRecipe, err)
+			return
+		}
+		if !authorizeOwner(c, resourceComment, recipe.UserID) {
+			return
+		}
+	}
+
+	if err := h.store.Comments.Delete(ctx, comment.ID, userID); err != nil {
+		respondError(c, resourceComment, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
+}
+
+// ReportRecipeComment コメントを通報してレビュー待ちに追加する（POST /reports の targetType=comment と同じ）
+func (h *Handler) ReportRecipeComment(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	comment := h.getRecipeComment(c)
+	if comment == nil {
+		return
+	}
+
+	var req models.ReportCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	report := models.CreateReportRequest{TargetType: models.ReportTargetComment, TargetID: comment.ID, Reason: req.Reason}
+	if _, err := h.store.Moderation.CreateReport(c.Request.Context(), userID, report); err != nil {
+		respondError(c, resourceComment, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "Comment reported"})
+}
@@ -0,0 +1,81 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/gin-gonic/gin"
+)
+
+// comment recipeID にコメントする（parentID を指定すると返信）
+func (c client) comment(recipeID, parentID, body string) *httptest.ResponseRecorder {
+	c.s.t.Helper()
+	return c.do(http.MethodPost, "/recipes/"+recipeID+"/comments", gin.H{"body": body, "parentId": parentID})
+}
+
+// comments recipeID のコメント一覧を取得
+func comments(t *testing.T, c client, recipeID string) []models.Comment {
+	t.Helper()
+	rec := c.do(http.MethodGet, "/recipes/"+recipeID+"/comments", nil)
+	expect(t, rec, http.StatusOK)
+	var page struct {
+		Items []models.Comment `json:"items"`
+	}
+	decode(t, rec, &page)
+	return page.Items
+}
+
+func TestCommentThread(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+
+	topID := created(t, s.as(bob).comment(recipeID, "", "Great recipe"), http.StatusCreated)
+	replyID := created(t, s.as(alice).comment(recipeID, topID, "Thanks!"), http.StatusCreated)
+	// 返信への返信はできない
+	expect(t, s.as(bob).comment(recipeID, replyID, "Nested"), http.StatusUnprocessableEntity)
+
+	thread := comments(t, s.anonymous(), recipeID)
+	if len(thread) != 1 || thread[0].ID != topID {
+		t.Fatalf("comments = %+v, want one top-level comment %s", thread, topID)
+	}
+	if len(thread[0].Replies) != 1 || thread[0].Replies[0].ID != replyID {
+		t.Fatalf("replies = %+v, want %s", thread[0].Replies, replyID)
+	}
+
+	// 投稿者以外は編集できない
+	path := "/recipes/" + recipeID + "/comments/" + topID
+	expect(t, s.as(alice).do(http.MethodPut, path, gin.H{"body": "Edited by someone else"}), http.StatusForbidden)
+	expect(t, s.as(bob).do(http.MethodPut, path, gin.H{"body": "Edited"}), http.StatusOK)
+
+	// 返信が残っているコメントは tombstone になる
+	expect(t, s.as(bob).do(http.MethodDelete, path, nil), http.StatusOK)
+	thread = comments(t, s.anonymous(), recipeID)
+	if len(thread) != 1 || !thread[0].Deleted || thread[0].Body != "" || thread[0].UserID != "" {
+		t.Fatalf("deleted comment = %+v, want a tombstone without body and author", thread)
+	}
+	expect(t, s.as(bob).comment(recipeID, topID, "Reply to deleted"), http.StatusUnprocessableEntity)
+}
+
+func TestRecipeAuthorCanDeleteComments(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+	commentID := created(t, s.as(bob).comment(recipeID, "", "Spam"), http.StatusCreated)
+	path := "/recipes/" + recipeID + "/comments/" + commentID
+
+	expect(t, s.as(carol).do(http.MethodDelete, path, nil), http.StatusForbidden)
+	expect(t, s.as(alice).do(http.MethodDelete, path, nil), http.StatusOK)
+	if thread := comments(t, s.anonymous(), recipeID); len(thread) != 0 {
+		t.Fatalf("comments after delete = %+v, want none", thread)
+	}
+}
+
+func TestCannotCommentOnPrivateRecipe(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(false)
+
+	expect(t, s.as(bob).comment(recipeID, "", "Hello"), http.StatusNotFound)
+	expect(t, s.as(bob).do(http.MethodGet, "/recipes/"+recipeID+"/comments", nil), http.StatusForbidden)
+	expect(t, s.anonymous().comment(recipeID, "", "Hello"), http.StatusUnauthorized)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Feed Handlers ==========
+
+// GetFeed フォロー中のユーザーの公開レシピと「Brewed it!」を新しい順に取得
+// クエリ: limit, cursor
+func (h *Handler) GetFeed(c *gin.Context) {
+	opts, ok := parseListOptions(c, repository.FeedSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Feed.Feed(c.Request.Context(), c.GetString("userID"), opts)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	for _, item := range page.Items {
+		if item.Recipe != nil {
+			brewing.AnnotateWater(item.Recipe)
+		}
+	}
+	c.JSON(http.StatusOK, page)
+}
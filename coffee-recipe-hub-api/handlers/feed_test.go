@@ -0,0 +1,77 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/gin-gonic/gin"
+)
+
+// feedItems フィードを取得して項目を返す
+func feedItems(t *testing.T, c client) []models.FeedItem {
+	t.Helper()
+	rec := c.do(http.MethodGet, "/feed", nil)
+	expect(t, rec, http.StatusOK)
+	var page struct {
+		Items []models.FeedItem `json:"items"`
+	}
+	decode(t, rec, &page)
+	return page.Items
+}
+
+func TestFeedShowsFollowedUsersPublicActivity(t *testing.T) {
+	s := newTestServer(t)
+	author := s.as(alice)
+	publicID := author.createRecipe(true)
+	author.createRecipe(false)
+	logID := author.createBrewLog(publicID, author.createBean())
+	expect(t, author.do(http.MethodPost, "/brew-logs/"+logID+"/share", nil), http.StatusOK)
+
+	follower := s.as(bob)
+	if items := feedItems(t, follower); len(items) != 0 {
+		t.Fatalf("feed before following has %d items, want 0", len(items))
+	}
+
+	expect(t, follower.do(http.MethodPost, "/users/"+alice+"/follow", nil), http.StatusOK)
+	items := feedItems(t, follower)
+	if len(items) != 2 {
+		t.Fatalf("feed has %d items, want the public recipe and the shared brew", len(items))
+	}
+	kinds := map[models.FeedItemType]string{}
+	for _, item := range items {
+		if item.UserID != alice {
+			t.Errorf("feed item %s is by %s, want %s", item.ID, item.UserID, alice)
+		}
+		kinds[item.Type] = item.ID
+	}
+	if kinds[models.FeedItemRecipe] != publicID {
+		t.Errorf("feed recipe = %q, want the public recipe %q", kinds[models.FeedItemRecipe], publicID)
+	}
+	if _, ok := kinds[models.FeedItemBrew]; !ok {
+		t.Error("feed has no shared brew")
+	}
+
+	// 公開をやめたレシピはフィードから消える
+	expect(t, author.do(http.MethodPut, "/recipes/"+publicID, recipeBody("Now private", false)), http.StatusOK)
+	for _, item := range feedItems(t, follower) {
+		if item.Type == models.FeedItemRecipe {
+			t.Errorf("private recipe %s is still in the feed", item.ID)
+		}
+	}
+
+	expect(t, follower.do(http.MethodDelete, "/users/"+alice+"/follow", nil), http.StatusOK)
+	if items := feedItems(t, follower); len(items) != 0 {
+		t.Fatalf("feed after unfollowing has %d items, want 0", len(items))
+	}
+}
+
+func TestFeedRequiresAuth(t *testing.T) {
+	s := newTestServer(t)
+	expect(t, s.anonymous().do(http.MethodGet, "/feed", nil), http.StatusUnauthorized)
+}
+
+func TestCannotFollowYourself(t *testing.T) {
+	s := newTestServer(t)
+	expect(t, s.as(alice).do(http.MethodPost, "/users/"+alice+"/follow", gin.H{}), http.StatusBadRequest)
+}
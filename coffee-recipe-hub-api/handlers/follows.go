@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Follow Handlers ==========
+
+// FollowUser ユーザーをフォロー
+func (h *Handler) FollowUser(c *gin.Context) {
+	userID := c.GetString("userID")
+	followeeID := c.Param("id")
+	if followeeID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot follow yourself"})
+		return
+	}
+
+	if err := h.store.Follows.Follow(c.Request.Context(), userID, followeeID); err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Followed", "following": true})
+}
+
+// UnfollowUser フォローを解除
+func (h *Handler) UnfollowUser(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	if err := h.store.Follows.Unfollow(c.Request.Context(), userID, c.Param("id")); err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Unfollowed", "following": false})
+}
+
+// GetFollowers フォロワー一覧取得
+// クエリ: limit, cursor, order（フォローした日時順）
+func (h *Handler) GetFollowers(c *gin.Context) {
+	opts, ok := parseListOptions(c, repository.FollowSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Follows.ListFollowers(c.Request.Context(), c.Param("id"), opts)
+	if err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// GetFollowing フォロー中のユーザー一覧取得
+// クエリ: limit, cursor, order（フォローした日時順）
+func (h *Handler) GetFollowing(c *gin.Context) {
+	opts, ok := parseListOptions(c, repository.FollowSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Follows.ListFollowing(c.Request.Context(), c.Param("id"), opts)
+	if err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,63 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/coffee-recipe-hub/api/repository/memory"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMalformedIDIsNotFound(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	recipeID := user.createRecipe(true)
+
+	for _, tc := range []struct {
+		method, path, want string
+	}{
+		{http.MethodGet, "/recipes/not-a-uuid", "Recipe not found"},
+		{http.MethodGet, "/beans/not-a-uuid", "Bean not found"},
+		{http.MethodDelete, "/brew-logs/1", "Brew log not found"},
+		{http.MethodGet, "/users/not-a-uuid", "User not found"},
+		{http.MethodPut, "/recipes/" + recipeID + "/comments/not-a-uuid", "Comment not found"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			rec := user.do(tc.method, tc.path, gin.H{"body": "Hi"})
+			expect(t, rec, http.StatusNotFound)
+			var body struct {
+				Error string `json:"error"`
+			}
+			decode(t, rec, &body)
+			if body.Error != tc.want {
+				t.Errorf("error = %q, want %q", body.Error, tc.want)
+			}
+		})
+	}
+}
+
+// failingBeans 作成が必ず失敗する豆のリポジトリ
+type failingBeans struct {
+	repository.BeanRepository
+}
+
+func (failingBeans) Create(ctx context.Context, userID string, req models.CreateBeanRequest) (*models.Bean, error) {
+	return nil, errors.New(`pq: relation "beans" does not exist`)
+}
+
+func TestInternalErrorsAreNotExposed(t *testing.T) {
+	store := memory.New()
+	store.Beans = failingBeans{store.Beans}
+	s := newTestServerWith(t, store)
+
+	rec := s.as(alice).do(http.MethodPost, "/beans", gin.H{"name": "Kenya AA"})
+	expect(t, rec, http.StatusInternalServerError)
+	if strings.Contains(rec.Body.String(), "pq:") {
+		t.Errorf("response exposes the database error: %s", rec.Body.String())
+	}
+}
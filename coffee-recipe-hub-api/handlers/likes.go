@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Like Handlers ==========
+
+// LikeRecipe レシピにいいね（公開レシピのみ。更新後のいいね数を返す）
+func (h *Handler) LikeRecipe(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	status, err := h.store.Likes.Like(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Liked", "liked": status.Liked, "likeCount": status.LikeCount})
+}
+
+// UnlikeRecipe レシピのいいねを取り消し（更新後のいいね数を返す）
+func (h *Handler) UnlikeRecipe(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	status, err := h.store.Likes.Unlike(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Unliked", "liked": status.Liked, "likeCount": status.LikeCount})
+}
+
+// CheckLikeStatus いいね状態を確認
+func (h *Handler) CheckLikeStatus(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusOK, gin.H{"liked": false})
+		return
+	}
+
+	exists, err := h.store.Likes.IsLiked(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"liked": exists})
+}
+
+// GetMyLikes いいねしたレシピ一覧取得
+// クエリ: limit, cursor, order（いいねした日時順）
+func (h *Handler) GetMyLikes(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	opts, ok := parseListOptions(c, repository.LikedRecipeSortKeys, "likedAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Likes.ListLiked(c.Request.Context(), userID, opts)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	annotateRecipes(page.Items)
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+// likeStatus いいね・取り消しのレスポンス
+type likeStatus struct {
+	Liked     bool `json:"liked"`
+	LikeCount int  `json:"likeCount"`
+}
+
+func like(t *testing.T, c client, method, recipeID string) likeStatus {
+	t.Helper()
+	rec := c.do(method, "/recipes/"+recipeID+"/like", nil)
+	expect(t, rec, http.StatusOK)
+	var status likeStatus
+	decode(t, rec, &status)
+	return status
+}
+
+func TestLikeAndUnlikeUpdateCount(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+
+	if got := like(t, s.as(bob), http.MethodPost, recipeID); got != (likeStatus{Liked: true, LikeCount: 1}) {
+		t.Errorf("like = %+v, want liked with 1 like", got)
+	}
+	// 2回目のいいねは数えない
+	if got := like(t, s.as(bob), http.MethodPost, recipeID); got.LikeCount != 1 {
+		t.Errorf("likeCount after liking twice = %d, want 1", got.LikeCount)
+	}
+	if got := like(t, s.as(alice), http.MethodPost, recipeID); got.LikeCount != 2 {
+		t.Errorf("likeCount after a second user = %d, want 2", got.LikeCount)
+	}
+
+	rec := s.as(bob).do(http.MethodGet, "/me/likes", nil)
+	expect(t, rec, http.StatusOK)
+	var page struct {
+		Items []models.Recipe `json:"items"`
+	}
+	decode(t, rec, &page)
+	if len(page.Items) != 1 || page.Items[0].ID != recipeID {
+		t.Errorf("liked recipes = %+v, want only %s", page.Items, recipeID)
+	}
+
+	if got := like(t, s.as(bob), http.MethodDelete, recipeID); got != (likeStatus{Liked: false, LikeCount: 1}) {
+		t.Errorf("unlike = %+v, want not liked with 1 like", got)
+	}
+	var status likeStatus
+	rec = s.as(bob).do(http.MethodGet, "/recipes/"+recipeID+"/like", nil)
+	expect(t, rec, http.StatusOK)
+	decode(t, rec, &status)
+	if status.Liked {
+		t.Error("like status is still liked after unliking")
+	}
+}
+
+func TestCannotLikePrivateOrMissingRecipe(t *testing.T) {
+	s := newTestServer(t)
+	privateID := s.as(alice).createRecipe(false)
+
+	expect(t, s.as(bob).do(http.MethodPost, "/recipes/"+privateID+"/like", nil), http.StatusNotFound)
+	expect(t, s.as(bob).do(http.MethodPost, "/recipes/"+missingID+"/like", nil), http.StatusNotFound)
+	expect(t, s.anonymous().do(http.MethodPost, "/recipes/"+privateID+"/like", nil), http.StatusUnauthorized)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// parseListOptions limit / cursor / sort / order クエリを読み取る
+// sort は sortKeys の許可リストにあるものだけ受け付け、不正な値は400を返してfalseを返す
+func parseListOptions(c *gin.Context, sortKeys []string, defaultSort string) (repository.ListOptions, bool) {
+	opts := repository.ListOptions{
+		Limit:  repository.DefaultLimit,
+		Cursor: c.Query("cursor"),
+		Sort:   c.DefaultQuery("sort", defaultSort),
+		Desc:   true,
+	}
+
+	if s := c.Query("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > repository.MaxLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", repository.MaxLimit)})
+			return opts, false
+		}
+		opts.Limit = limit
+	}
+
+	if !slices.Contains(sortKeys, opts.Sort) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort key", "allowed": sortKeys})
+		return opts, false
+	}
+
+	switch c.DefaultQuery("order", "desc") {
+	case "desc":
+	case "asc":
+		opts.Desc = false
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be 'asc' or 'desc'"})
+		return opts, false
+	}
+	return opts, true
+}
+
+// parseDateRange from / to クエリを読み取る（YYYY-MM-DD または RFC3339）
+// 日付のみの to はその日の終わりまでを含む
+func parseDateRange(c *gin.Context) (from, to *time.Time, ok bool) {
+	parse := func(name string, endOfDay bool) (*time.Time, bool) {
+		s := c.Query(name)
+		if s == "" {
+			return nil, true
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return &t, true
+		}
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be a date (YYYY-MM-DD) or RFC3339 timestamp"})
+			return nil, false
+		}
+		if endOfDay {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		return &t, true
+	}
+
+	if from, ok = parse("from", false); !ok {
+		return nil, nil, false
+	}
+	if to, ok = parse("to", true); !ok {
+		return nil, nil, false
+	}
+	return from, to, true
+}
+
+// parseIntQuery 整数のクエリを読み取る（未指定なら0）
+func parseIntQuery(c *gin.Context, name string, min, max int) (int, bool) {
+	s := c.Query(name)
+	if s == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s must be between %d and %d", name, min, max)})
+		return 0, false
+	}
+	return n, true
+}
+
+// parseFloatQuery 数値のクエリを読み取る（未指定なら0）
+func parseFloatQuery(c *gin.Context, name string, min, max float64) (float64, bool) {
+	s := c.Query(name)
+	if s == "" {
+		return 0, true
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < min || n > max {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s must be between %g and %g", name, min, max)})
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Moderation Handlers ==========
+
+// reportTargetKinds 通報の対象が見つからないときのリソース名
+var reportTargetKinds = map[models.ReportTargetType]resourceKind{
+	models.ReportTargetRecipe:  resourceRecipe,
+	models.ReportTargetComment: resourceComment,
+	models.ReportTargetUser:    resourceUser,
+}
+
+// CreateReport 公開レシピ・コメント・ユーザーを通報してレビュー待ちに追加する
+// 同じ対象を通報済みなら既存の通報を返す
+func (h *Handler) CreateReport(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req models.CreateReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	report, err := h.store.Moderation.CreateReport(c.Request.Context(), userID, req)
+	if err != nil {
+		respondError(c, reportTargetKinds[req.TargetType], err)
+		return
+	}
+	c.JSON(http.StatusCreated, report)
+}
+
+// RejectBannedUsers 利用停止中のユーザーの書き込み（GET 以外）を403で拒否するミドルウェア
+// 利用停止状態は banCacheTTL の間キャッシュする
+func (h *Handler) RejectBannedUsers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userID")
+		if userID == "" || c.Request.Method == http.MethodGet {
+			c.Next()
+			return
+		}
+		banned, err := h.isBanned(c, userID)
+		if err != nil {
+			respondInternalError(c, err)
+			c.Abort()
+			return
+		}
+		if banned {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Your account has been suspended"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// isBanned キャッシュになければ DB に問い合わせる
+func (h *Handler) isBanned(c *gin.Context, userID string) (bool, error) {
+	now := time.Now()
+	if banned, ok := h.bans.get(userID, now); ok {
+		return banned, nil
+	}
+	gen := h.bans.generation()
+	banned, err := h.store.Moderation.IsBanned(c.Request.Context(), userID)
+	if err != nil {
+		return false, err
+	}
+	h.bans.set(userID, banned, gen, now)
+	return banned, nil
+}
+
+// GetReports 通報一覧（管理者用、新しい順）
+// クエリ: limit, cursor, order, status（pending / resolved / dismissed）, targetType（recipe / comment / user）
+func (h *Handler) GetReports(c *gin.Context) {
+	opts, ok := parseListOptions(c, repository.ReportSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Moderation.ListReports(c.Request.Context(), repository.ReportFilter{
+		ListOptions: opts,
+		Status:      models.ReportStatus(c.Query("status")),
+		TargetType:  models.ReportTargetType(c.Query("targetType")),
+	})
+	if err != nil {
+		respondError(c, resourceReport, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// UpdateReport 通報を対応済み・問題なしにする（管理者用）
+func (h *Handler) UpdateReport(c *gin.Context) {
+	var req models.UpdateReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	report, err := h.store.Moderation.UpdateReport(c.Request.Context(), c.Param("id"), c.GetString("userID"), req.Status)
+	if err != nil {
+		respondError(c, resourceReport, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// setHidden レシピ・コメントを非表示にする（または戻す）
+func (h *Handler) setHidden(c *gin.Context, targetType models.ReportTargetType, hidden bool) {
+	kind := reportTargetKinds[targetType]
+	err := h.store.Moderation.SetHidden(c.Request.Context(), targetType, c.Param("id"), c.GetString("userID"), hidden)
+	if err != nil {
+		respondError(c, kind, err)
+		return
+	}
+	message := string(kind) + " hidden"
+	if !hidden {
+		message = string(kind) + " unhidden"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// HideRecipe レシピを非表示にする（管理者用）
+// 作者本人以外には非公開と同じく見えなくなり、レシピへのレビュー待ちの通報は対応済みになる
+func (h *Handler) HideRecipe(c *gin.Context) {
+	h.setHidden(c, models.ReportTargetRecipe, true)
+}
+
+// UnhideRecipe レシピの非表示を解除する（管理者用）
+func (h *Handler) UnhideRecipe(c *gin.Context) {
+	h.setHidden(c, models.ReportTargetRecipe, false)
+}
+
+// HideComment コメントを非表示にする（管理者用）
+// 返信が残っているコメントは一覧に tombstone として残る
+func (h *Handler) HideComment(c *gin.Context) {
+	h.setHidden(c, models.ReportTargetComment, true)
+}
+
+// UnhideComment コメントの非表示を解除する（管理者用）
+func (h *Handler) UnhideComment(c *gin.Context) {
+	h.setHidden(c, models.ReportTargetComment, false)
+}
+
+// BanUser ユーザーを利用停止にする（管理者用）
+// レシピ・コメント・Brewed it! がすべて非表示になり、プロフィールも公開されず、書き込みができなくなる
+func (h *Handler) BanUser(c *gin.Context) {
+	userID := c.Param("id")
+	adminID := c.GetString("userID")
+	if userID == adminID {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Cannot ban yourself"})
+		return
+	}
+
+	if err := h.store.Moderation.Ban(c.Request.Context(), userID, adminID); err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	h.bans.forget(userID)
+	c.JSON(http.StatusOK, gin.H{"message": "User banned"})
+}
+
+// UnbanUser 利用停止を解除する（管理者用）
+// 利用停止に伴って非表示にしたコンテンツは戻るが、個別に非表示にしたものは非表示のまま
+func (h *Handler) UnbanUser(c *gin.Context) {
+	if err := h.store.Moderation.Unban(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	h.bans.forget(c.Param("id"))
+	c.JSON(http.StatusOK, gin.H{"message": "User unbanned"})
+}
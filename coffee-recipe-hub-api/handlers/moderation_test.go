@@ -0,0 +1,140 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/coffee-recipe-hub/api/repository/memory"
+	"github.com/gin-gonic/gin"
+)
+
+// reports 管理者として status の通報一覧を取得
+func reports(t *testing.T, s *testServer, status models.ReportStatus) []models.Report {
+	t.Helper()
+	rec := s.asAdmin(admin).do(http.MethodGet, "/admin/reports?status="+string(status), nil)
+	expect(t, rec, http.StatusOK)
+	var page struct {
+		Items []models.Report `json:"items"`
+	}
+	decode(t, rec, &page)
+	return page.Items
+}
+
+func TestReportIsDeduplicatedPerReporter(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+	report := gin.H{"targetType": "recipe", "targetId": recipeID, "reason": "Spam"}
+
+	first := created(t, s.as(bob).do(http.MethodPost, "/reports", report), http.StatusCreated)
+	second := created(t, s.as(bob).do(http.MethodPost, "/reports", report), http.StatusCreated)
+	if first != second {
+		t.Errorf("reporting twice created %s and %s, want the same report", first, second)
+	}
+	if pending := reports(t, s, models.ReportPending); len(pending) != 1 {
+		t.Fatalf("pending reports = %d, want 1", len(pending))
+	}
+
+	// 非公開・存在しない対象は通報できない
+	privateID := s.as(alice).createRecipe(false)
+	expect(t, s.as(bob).do(http.MethodPost, "/reports", gin.H{"targetType": "recipe", "targetId": privateID, "reason": "Spam"}), http.StatusNotFound)
+	expect(t, s.as(bob).do(http.MethodPost, "/reports", gin.H{"targetType": "user", "targetId": missingID, "reason": "Spam"}), http.StatusNotFound)
+}
+
+func TestAdminRoutesRequireAdminRole(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+
+	expect(t, s.anonymous().do(http.MethodGet, "/admin/reports", nil), http.StatusUnauthorized)
+	expect(t, s.as(bob).do(http.MethodGet, "/admin/reports", nil), http.StatusForbidden)
+	expect(t, s.as(bob).do(http.MethodPost, "/admin/recipes/"+recipeID+"/hide", nil), http.StatusForbidden)
+	expect(t, s.as(bob).do(http.MethodPost, "/admin/users/"+alice+"/ban", nil), http.StatusForbidden)
+}
+
+func TestHideRecipeResolvesReports(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+	path := "/recipes/" + recipeID
+	created(t, s.as(bob).do(http.MethodPost, "/reports", gin.H{"targetType": "recipe", "targetId": recipeID, "reason": "Spam"}), http.StatusCreated)
+
+	expect(t, s.asAdmin(admin).do(http.MethodPost, "/admin"+path+"/hide", nil), http.StatusOK)
+	expect(t, s.as(bob).do(http.MethodGet, path, nil), http.StatusForbidden)
+	expect(t, s.as(alice).do(http.MethodGet, path, nil), http.StatusOK)
+	if pending := reports(t, s, models.ReportPending); len(pending) != 0 {
+		t.Errorf("pending reports after hiding = %d, want 0", len(pending))
+	}
+	if resolved := reports(t, s, models.ReportResolved); len(resolved) != 1 || resolved[0].ResolvedBy != admin {
+		t.Errorf("resolved reports = %+v, want one resolved by %s", resolved, admin)
+	}
+
+	expect(t, s.asAdmin(admin).do(http.MethodDelete, "/admin"+path+"/hide", nil), http.StatusOK)
+	expect(t, s.as(bob).do(http.MethodGet, path, nil), http.StatusOK)
+}
+
+func TestHiddenCommentIsRemovedFromThread(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(alice).createRecipe(true)
+	commentID := created(t, s.as(bob).comment(recipeID, "", "Spam"), http.StatusCreated)
+	expect(t, s.as(alice).do(http.MethodPost, "/recipes/"+recipeID+"/comments/"+commentID+"/report", gin.H{"reason": "Spam"}), http.StatusAccepted)
+
+	expect(t, s.asAdmin(admin).do(http.MethodPost, "/admin/comments/"+commentID+"/hide", nil), http.StatusOK)
+	if thread := comments(t, s.anonymous(), recipeID); len(thread) != 0 {
+		t.Errorf("comments after hiding = %+v, want none", thread)
+	}
+	if pending := reports(t, s, models.ReportPending); len(pending) != 0 {
+		t.Errorf("pending reports after hiding = %d, want 0", len(pending))
+	}
+}
+
+func TestBanBlocksWritesAndHidesContent(t *testing.T) {
+	s := newTestServer(t)
+	recipeID := s.as(bob).createRecipe(true)
+	path := "/recipes/" + recipeID
+
+	expect(t, s.asAdmin(admin).do(http.MethodPost, "/admin/users/"+admin+"/ban", nil), http.StatusUnprocessableEntity)
+	expect(t, s.asAdmin(admin).do(http.MethodPost, "/admin/users/"+bob+"/ban", nil), http.StatusOK)
+
+	banned := s.as(bob)
+	expect(t, banned.do(http.MethodPost, "/recipes", recipeBody("After ban", true)), http.StatusForbidden)
+	expect(t, banned.do(http.MethodGet, path, nil), http.StatusOK) // 読み取りはできる
+	expect(t, s.as(alice).do(http.MethodGet, path, nil), http.StatusForbidden)
+	expect(t, s.as(alice).do(http.MethodGet, "/users/"+bob, nil), http.StatusNotFound)
+
+	expect(t, s.asAdmin(admin).do(http.MethodDelete, "/admin/users/"+bob+"/ban", nil), http.StatusOK)
+	expect(t, s.as(alice).do(http.MethodGet, path, nil), http.StatusOK)
+	created(t, banned.do(http.MethodPost, "/recipes", recipeBody("After unban", true)), http.StatusCreated)
+}
+
+// countingModeration 利用停止状態の問い合わせ回数を数えるリポジトリ
+type countingModeration struct {
+	repository.ModerationRepository
+	isBannedCalls map[string]int
+}
+
+func (m *countingModeration) IsBanned(ctx context.Context, userID string) (bool, error) {
+	m.isBannedCalls[userID]++
+	return m.ModerationRepository.IsBanned(ctx, userID)
+}
+
+func TestBanStatusIsCachedBetweenWrites(t *testing.T) {
+	store := memory.New()
+	moderation := &countingModeration{ModerationRepository: store.Moderation, isBannedCalls: map[string]int{}}
+	store.Moderation = moderation
+	s := newTestServerWith(t, store)
+
+	user := s.as(bob)
+	for range 3 {
+		user.createRecipe(true)
+	}
+	if got := moderation.isBannedCalls[bob]; got != 1 {
+		t.Errorf("IsBanned called %d times for 3 writes, want 1", got)
+	}
+
+	// 利用停止・解除はキャッシュを待たずに反映される
+	expect(t, s.asAdmin(admin).do(http.MethodPost, "/admin/users/"+bob+"/ban", nil), http.StatusOK)
+	expect(t, user.do(http.MethodPost, "/recipes", recipeBody("After ban", true)), http.StatusForbidden)
+	expect(t, s.asAdmin(admin).do(http.MethodDelete, "/admin/users/"+bob+"/ban", nil), http.StatusOK)
+	created(t, user.do(http.MethodPost, "/recipes", recipeBody("After unban", true)), http.StatusCreated)
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Notification Handlers ==========
+
+// GetNotifications 通知の受信箱（新しい順）
+// クエリ: limit, cursor, order, unread（true なら未読のみ）
+func (h *Handler) GetNotifications(c *gin.Context) {
+	opts, ok := parseListOptions(c, repository.NotificationSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Notifications.ListByUser(c.Request.Context(), c.GetString("userID"), repository.NotificationFilter{
+		ListOptions: opts,
+		UnreadOnly:  c.Query("unread") == "true",
+	})
+	if err != nil {
+		respondError(c, resourceNotification, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// MarkNotificationRead 通知を既読にする
+func (h *Handler) MarkNotificationRead(c *gin.Context) {
+	// 他人の通知は存在しないものとして404を返す
+	if err := h.store.Notifications.MarkRead(c.Request.Context(), c.Param("id"), c.GetString("userID")); err != nil {
+		respondError(c, resourceNotification, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
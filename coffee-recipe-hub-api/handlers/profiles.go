@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/auth"
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Profile Handlers ==========
+
+// userProfileResponse 公開プロフィール（集計と公開レシピの1ページ目を含む）
+type userProfileResponse struct {
+	models.User
+	Stats   models.UserStats               `json:"stats"`
+	Recipes repository.Page[models.Recipe] `json:"recipes"`
+}
+
+// GetMe 自分のプロフィール取得（なければ作成する）
+func (h *Handler) GetMe(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	user, err := h.store.Profiles.Ensure(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	user.Email = c.GetString(auth.ContextUserEmail)
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateMe 自分のプロフィール更新
+// 表示名はレシピの作者名として表示される
+func (h *Handler) UpdateMe(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req models.UpdateProfileRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.store.Profiles.Update(c.Request.Context(), userID, req)
+	if err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	user.Email = c.GetString(auth.ContextUserEmail)
+	c.JSON(http.StatusOK, user)
+}
+
+// GetUserProfile ユーザーの公開プロフィール取得
+// クエリ: 公開レシピ一覧と同じ（limit, cursor, sort, order, equipment, grindSize, tag, from, to）
+func (h *Handler) GetUserProfile(c *gin.Context) {
+	userID := c.Param("id")
+
+	filter, ok := parseRecipeFilter(c, repository.PublicRecipeSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+	filter.UserID = userID
+	filter.ViewerID = c.GetString("userID")
+
+	ctx := c.Request.Context()
+	user, err := h.store.Profiles.Get(ctx, userID)
+	// 利用停止中のユーザーは本人以外には存在しないものとして扱う
+	if err == nil && user.Banned && userID != filter.ViewerID {
+		err = repository.ErrNotFound
+	}
+	if err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	stats, err := h.store.Profiles.Stats(ctx, userID)
+	if err != nil {
+		respondError(c, resourceUser, err)
+		return
+	}
+	recipes, err := h.store.Recipes.ListPublic(ctx, filter)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	annotateRecipes(recipes.Items)
+
+	c.JSON(http.StatusOK, userProfileResponse{User: *user, Stats: *stats, Recipes: recipes})
+}
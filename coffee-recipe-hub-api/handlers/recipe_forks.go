@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Recipe Fork Handlers ==========
+
+// ForkRecipe 公開レシピを自分の非公開レシピとしてコピーする
+// 本文（title）は省略可。コピーには元のレシピ・版・作者を記録する
+func (h *Handler) ForkRecipe(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	var req models.ForkRecipeRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	fork, err := h.store.Recipes.Fork(ctx, id, c.GetString("userID"), req)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	brewing.AnnotateWater(fork)
+	c.JSON(http.StatusCreated, fork)
+}
+
+// GetRecipeForks レシピから派生したフォークの系統取得
+// 閲覧できない（他人の非公開）フォークとその先は含めない
+func (h *Handler) GetRecipeForks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	forks, err := h.store.Recipes.ListForks(ctx, recipe.ID, c.GetString("userID"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.RecipeForkTree{
+		RecipeID:  recipe.ID,
+		ForkCount: recipe.ForkCount,
+		Forks:     forkNodes(recipe.ID, forks),
+	})
+}
+
+// forkNodes フォークの一覧（作成日順）を parentID を根とする木にする
+func forkNodes(parentID string, forks []models.Recipe) []models.RecipeForkNode {
+	children := map[string][]models.Recipe{}
+	for _, fork := range forks {
+		children[fork.ForkedFromRecipeID] = append(children[fork.ForkedFromRecipeID], fork)
+	}
+
+	var build func(id string) []models.RecipeForkNode
+	build = func(id string) []models.RecipeForkNode {
+		nodes := []models.RecipeForkNode{}
+		for _, fork := range children[id] {
+			nodes = append(nodes, models.RecipeForkNode{
+				ID:                fork.ID,
+				UserID:            fork.UserID,
+				AuthorName:        fork.AuthorName,
+				Title:             fork.Title,
+				IsPublic:          fork.IsPublic,
+				LikeCount:         fork.LikeCount,
+				ForkCount:         fork.ForkCount,
+				ForkedFromVersion: fork.ForkedFromVersion,
+				CreatedAt:         fork.CreatedAt,
+				Forks:             build(fork.ID),
+			})
+		}
+		return nodes
+	}
+	return build(parentID)
+}
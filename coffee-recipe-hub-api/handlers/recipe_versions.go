@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Recipe Version Handlers ==========
+
+// GetRecipeVersions レシピの版の一覧取得
+// クエリ: limit, cursor, order（既定は新しい版から）
+func (h *Handler) GetRecipeVersions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	opts, ok := parseListOptions(c, repository.RecipeVersionSortKeys, "version")
+	if !ok {
+		return
+	}
+
+	page, err := h.store.Recipes.ListVersions(ctx, recipe.ID, opts)
+	if err != nil {
+		respondError(c, resourceRecipeVersion, err)
+		return
+	}
+	for i := range page.Items {
+		brewing.AnnotateRevisionWater(&page.Items[i])
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// GetRecipeVersion レシピのある版の内容取得
+func (h *Handler) GetRecipeVersion(c *gin.Context) {
+	version, ok := parseVersionParam(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	rev, err := h.store.Recipes.GetVersion(ctx, recipe.ID, version)
+	if err != nil {
+		respondError(c, resourceRecipeVersion, err)
+		return
+	}
+	brewing.AnnotateRevisionWater(rev)
+	c.JSON(http.StatusOK, rev)
+}
+
+// GetRecipeDiff 2つの版の差分取得
+// クエリ: to（既定は現在の版）, from（既定は to の1つ前の版）
+func (h *Handler) GetRecipeDiff(c *gin.Context) {
+	from, ok := parseIntQuery(c, "from", 1, math.MaxInt32)
+	if !ok {
+		return
+	}
+	to, ok := parseIntQuery(c, "to", 1, math.MaxInt32)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	if to == 0 {
+		to = recipe.Version
+	}
+	if from == 0 {
+		if to == 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Version 1 has no previous version; specify from"})
+			return
+		}
+		from = to - 1
+	}
+
+	fromRev, err := h.store.Recipes.GetVersion(ctx, recipe.ID, from)
+	if err != nil {
+		respondError(c, resourceRecipeVersion, err)
+		return
+	}
+	toRev, err := h.store.Recipes.GetVersion(ctx, recipe.ID, to)
+	if err != nil {
+		respondError(c, resourceRecipeVersion, err)
+		return
+	}
+	brewing.AnnotateRevisionWater(fromRev)
+	brewing.AnnotateRevisionWater(toRev)
+	c.JSON(http.StatusOK, brewing.Diff(fromRev, toRev))
+}
+
+// RestoreRecipeVersion 過去の版の内容を新しい版としてレシピに戻す（元の版は残る）
+func (h *Handler) RestoreRecipeVersion(c *gin.Context) {
+	version, ok := parseVersionParam(c)
+	if !ok {
+		return
+	}
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeOwner(c, resourceRecipe, recipe.UserID) {
+		return
+	}
+	if _, err := h.store.Recipes.GetVersion(ctx, id, version); err != nil {
+		respondError(c, resourceRecipeVersion, err)
+		return
+	}
+
+	restored, err := h.store.Recipes.RestoreVersion(ctx, id, c.GetString("userID"), version)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	brewing.AnnotateWater(restored)
+	c.JSON(http.StatusOK, restored)
+}
+
+// parseVersionParam パスの版番号を読み取る
+func parseVersionParam(c *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "version must be a positive integer"})
+		return 0, false
+	}
+	return version, true
+}
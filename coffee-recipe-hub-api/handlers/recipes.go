@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/coffee-recipe-hub/api/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Recipe Handlers ==========
+
+// parseRecipeFilter レシピ一覧共通のクエリを読み取る
+// クエリ: limit, cursor, sort, order, equipment, grindSize, tag, from, to（作成日）
+func parseRecipeFilter(c *gin.Context, sortKeys []string, defaultSort string) (repository.RecipeFilter, bool) {
+	opts, ok := parseListOptions(c, sortKeys, defaultSort)
+	if !ok {
+		return repository.RecipeFilter{}, false
+	}
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return repository.RecipeFilter{}, false
+	}
+	return repository.RecipeFilter{
+		ListOptions: opts,
+		Equipment:   models.Equipment(c.Query("equipment")),
+		GrindSize:   models.GrindSize(c.Query("grindSize")),
+		Tag:         c.Query("tag"),
+		From:        from,
+		To:          to,
+	}, true
+}
+
+// GetRecipes レシピ一覧取得
+func (h *Handler) GetRecipes(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	filter, ok := parseRecipeFilter(c, repository.RecipeSortKeys, "createdAt")
+	if !ok {
+		return
+	}
+	filter.ViewerID = userID
+
+	page, err := h.store.Recipes.ListVisible(c.Request.Context(), userID, filter)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	annotateRecipes(page.Items)
+	c.JSON(http.StatusOK, page)
+}
+
+// GetPublicRecipes 公開レシピ一覧取得（コミュニティ用）
+// ログイン中なら各レシピに likedByMe を設定する
+// sort=trending（既定）は最近のいいね・フォーク・「Brewed it!」を時間で減衰させたスコア、top は期間内の合計、new は新しい順
+// スコアはバックグラウンドジョブで定期的に集計し直す
+func (h *Handler) GetPublicRecipes(c *gin.Context) {
+	filter, ok := parseRecipeFilter(c, repository.PublicRecipeSortKeys, "trending")
+	if !ok {
+		return
+	}
+	filter.ViewerID = c.GetString("userID")
+
+	page, err := h.store.Recipes.ListPublic(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	annotateRecipes(page.Items)
+	c.JSON(http.StatusOK, page)
+}
+
+// GetRecipe レシピ詳細取得
+// クエリ: waterMode（per_pour / cumulative を指定するとステップの waterMl をそのモードに変換して返す）
+func (h *Handler) GetRecipe(c *gin.Context) {
+	recipe, err := h.store.Recipes.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	brewing.AnnotateWater(recipe)
+	if mode := models.StepWaterMode(c.Query("waterMode")); mode != "" {
+		if mode != models.StepWaterPerPour && mode != models.StepWaterCumulative {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "waterMode must be 'per_pour' or 'cumulative'"})
+			return
+		}
+		brewing.ConvertWaterMode(recipe, mode)
+	}
+	c.JSON(http.StatusOK, recipe)
+}
+
+// annotateRecipes 各ステップに累計湯量と1投ごとの量を設定する
+func annotateRecipes(recipes []models.Recipe) {
+	for i := range recipes {
+		brewing.AnnotateWater(&recipes[i])
+	}
+}
+
+// GetScaledRecipe 豆量・湯量を変えて計算し直したレシピ取得
+// クエリ: coffeeGrams（豆量）, totalWaterMl（湯量）, ratio（豆量はそのままで湯量/豆量の比）のいずれか1つ
+func (h *Handler) GetScaledRecipe(c *gin.Context) {
+	coffeeGrams, ok := parseFloatQuery(c, "coffeeGrams", 1, 1000)
+	if !ok {
+		return
+	}
+	totalWaterMl, ok := parseIntQuery(c, "totalWaterMl", 10, 10000)
+	if !ok {
+		return
+	}
+	ratio, ok := parseFloatQuery(c, "ratio", 1, 30)
+	if !ok {
+		return
+	}
+	given := 0
+	for _, v := range []float64{coffeeGrams, float64(totalWaterMl), ratio} {
+		if v > 0 {
+			given++
+		}
+	}
+	if given != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Specify exactly one of coffeeGrams, totalWaterMl or ratio"})
+		return
+	}
+
+	recipe, err := h.store.Recipes.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeRecipeView(c, recipe) {
+		return
+	}
+
+	scaled, err := brewing.Scale(recipe, brewing.ScaleTarget{
+		CoffeeGrams:  coffeeGrams,
+		TotalWaterMl: totalWaterMl,
+		Ratio:        ratio,
+	})
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, scaled)
+}
+
+// CreateRecipe レシピ作成
+func (h *Handler) CreateRecipe(c *gin.Context) {
+	var req models.CreateRecipeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if req.StepWaterMode == "" {
+		req.StepWaterMode = models.StepWaterPerPour
+	}
+	if errs := validation.Recipe(&req); len(errs) > 0 {
+		respondValidation(c, errs)
+		return
+	}
+
+	userID := c.GetString("userID")
+
+	recipe, err := h.store.Recipes.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		respondInternalError(c, err)
+		return
+	}
+	brewing.AnnotateWater(recipe)
+	c.JSON(http.StatusCreated, recipe)
+}
+
+// UpdateRecipe レシピ更新
+func (h *Handler) UpdateRecipe(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeOwner(c, resourceRecipe, recipe.UserID) {
+		return
+	}
+
+	var req models.CreateRecipeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	// 省略時は今の注湯量の表し方のまま
+	if req.StepWaterMode == "" {
+		req.StepWaterMode = recipe.StepWaterMode
+	}
+	if errs := validation.Recipe(&req); len(errs) > 0 {
+		respondValidation(c, errs)
+		return
+	}
+
+	if err := h.store.Recipes.Update(ctx, id, c.GetString("userID"), req); err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Recipe updated"})
+}
+
+// DeleteRecipe レシピ削除
+func (h *Handler) DeleteRecipe(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	recipe, err := h.store.Recipes.Get(ctx, id)
+	if err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	if !authorizeOwner(c, resourceRecipe, recipe.UserID) {
+		return
+	}
+
+	if err := h.store.Recipes.Delete(ctx, id, c.GetString("userID")); err != nil {
+		respondError(c, resourceRecipe, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Recipe deleted"})
+}
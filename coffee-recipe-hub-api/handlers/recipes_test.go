@@ -0,0 +1,36 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestUpdateRecipeKeepsStepWaterModeWhenOmitted(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	body := recipeBody("Cumulative V60", false)
+	body["stepWaterMode"] = "cumulative"
+	body["steps"] = []gin.H{
+		{"order": 1, "label": "Bloom", "timeSeconds": 30, "waterMl": 50},
+		{"order": 2, "label": "Pour", "timeSeconds": 90, "waterMl": 250},
+	}
+	recipeID := created(t, user.do(http.MethodPost, "/recipes", body), http.StatusCreated)
+
+	delete(body, "stepWaterMode")
+	body["title"] = "Renamed"
+	expect(t, user.do(http.MethodPut, "/recipes/"+recipeID, body), http.StatusOK)
+
+	rec := user.do(http.MethodGet, "/recipes/"+recipeID, nil)
+	expect(t, rec, http.StatusOK)
+	var recipe models.Recipe
+	decode(t, rec, &recipe)
+	if recipe.StepWaterMode != models.StepWaterCumulative {
+		t.Errorf("stepWaterMode = %q, want %q", recipe.StepWaterMode, models.StepWaterCumulative)
+	}
+	if recipe.Steps[1].WaterMl != 250 {
+		t.Errorf("steps[1].waterMl = %d, want 250", recipe.Steps[1].WaterMl)
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/coffee-recipe-hub/api/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// uuidPattern UUID の文字列表現（PostgreSQL の uuid 型の出力と同じ形式）
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// idParamKinds UUID のパスパラメーターの直前のセグメントと、見つからないときのリソース名
+var idParamKinds = map[string]resourceKind{
+	"beans":         resourceBean,
+	"recipes":       resourceRecipe,
+	"comments":      resourceComment,
+	"brew-logs":     resourceBrewLog,
+	"users":         resourceUser,
+	"notifications": resourceNotification,
+	"reports":       resourceReport,
+}
+
+// requireUUIDParams UUID でない id のリクエストを、DBに問い合わせずに404で返すミドルウェア
+// 例: /recipes/:id/comments/:commentId の :id と :commentId
+func requireUUIDParams() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		segments := strings.Split(c.FullPath(), "/")
+		for i := 1; i < len(segments); i++ {
+			name, ok := strings.CutPrefix(segments[i], ":")
+			if !ok {
+				continue
+			}
+			kind, ok := idParamKinds[segments[i-1]]
+			if ok && !uuidPattern.MatchString(c.Param(name)) {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": string(kind) + " not found"})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// RegisterRoutes API v1 のルートを登録する
+// 認証ミドルウェア（auth.Middleware）は呼び出し側で先に登録しておくこと
+func (h *Handler) RegisterRoutes(r gin.IRouter) {
+	requireAuth := auth.RequireAuth()
+
+	// id の形式を確かめ、利用停止中のユーザーの書き込みを拒否する
+	v1 := r.Group("/api/v1", requireUUIDParams(), h.RejectBannedUsers())
+	{
+		// Beans
+		beans := v1.Group("/beans", requireAuth)
+		{
+			beans.GET("", h.GetBeans)
+			beans.GET("/ready", h.GetReadyBeans) // 飲み頃の豆
+			beans.GET("/:id", h.GetBean)
+			beans.POST("", h.CreateBean)
+			beans.PUT("/:id", h.UpdateBean)
+			beans.DELETE("/:id", h.DeleteBean)
+			// 在庫台帳
+			beans.GET("/:id/stock-history", h.GetBeanStockHistory)
+			beans.POST("/:id/stock-movements", h.CreateStockMovement)
+		}
+
+		// Recipes
+		recipes := v1.Group("/recipes")
+		{
+			recipes.GET("", requireAuth, h.GetRecipes)
+			recipes.GET("/public", h.GetPublicRecipes) // 公開レシピ一覧
+			recipes.GET("/:id", h.GetRecipe)
+			recipes.GET("/:id/scaled", h.GetScaledRecipe) // 分量を変えたレシピ
+			recipes.POST("", requireAuth, h.CreateRecipe)
+			recipes.PUT("/:id", requireAuth, h.UpdateRecipe)
+			recipes.DELETE("/:id", requireAuth, h.DeleteRecipe)
+			// 版の履歴
+			recipes.GET("/:id/versions", h.GetRecipeVersions)
+			recipes.GET("/:id/versions/:version", h.GetRecipeVersion)
+			recipes.POST("/:id/versions/:version/restore", requireAuth, h.RestoreRecipeVersion)
+			recipes.GET("/:id/diff", h.GetRecipeDiff) // 版の差分
+			// フォーク（自分用のコピー）
+			recipes.POST("/:id/fork", requireAuth, h.ForkRecipe)
+			recipes.GET("/:id/forks", h.GetRecipeForks)
+			recipes.GET("/:id/brews", h.GetRecipeBrews) // Brewed it!
+			// コメント（返信は1階層まで）
+			recipes.GET("/:id/comments", h.GetRecipeComments)
+			recipes.POST("/:id/comments", requireAuth, h.CreateRecipeComment)
+			recipes.PUT("/:id/comments/:commentId", requireAuth, h.UpdateRecipeComment)
+			recipes.DELETE("/:id/comments/:commentId", requireAuth, h.DeleteRecipeComment)
+			recipes.POST("/:id/comments/:commentId/report", requireAuth, h.ReportRecipeComment)
+			// いいね機能
+			recipes.POST("/:id/like", requireAuth, h.LikeRecipe)
+			recipes.DELETE("/:id/like", requireAuth, h.UnlikeRecipe)
+			recipes.GET("/:id/like", h.CheckLikeStatus)
+		}
+
+		// 全文検索
+		v1.GET("/search", h.Search)
+
+		// BrewLogs
+		brewLogs := v1.Group("/brew-logs", requireAuth)
+		{
+			brewLogs.GET("", h.GetBrewLogs)
+			brewLogs.GET("/:id", h.GetBrewLog)
+			brewLogs.POST("", h.CreateBrewLog)
+			brewLogs.PUT("/:id", h.UpdateBrewLog)
+			brewLogs.DELETE("/:id", h.DeleteBrewLog)
+			// レシピへの「Brewed it!」として公開
+			brewLogs.POST("/:id/share", h.ShareBrewLog)
+			brewLogs.DELETE("/:id/share", h.UnshareBrewLog)
+		}
+
+		// 自分のプロフィール
+		v1.GET("/me", requireAuth, h.GetMe)
+		v1.PUT("/me", requireAuth, h.UpdateMe)
+		v1.GET("/me/likes", requireAuth, h.GetMyLikes) // いいねしたレシピ
+
+		// Users（公開プロフィール・フォロー）
+		users := v1.Group("/users")
+		{
+			users.GET("/:id", h.GetUserProfile)
+			users.POST("/:id/follow", requireAuth, h.FollowUser)
+			users.DELETE("/:id/follow", requireAuth, h.UnfollowUser)
+			users.GET("/:id/followers", h.GetFollowers)
+			users.GET("/:id/following", h.GetFollowing)
+		}
+
+		// フォロー中のユーザーの新着
+		v1.GET("/feed", requireAuth, h.GetFeed)
+
+		// Notifications
+		notifications := v1.Group("/notifications", requireAuth)
+		{
+			notifications.GET("", h.GetNotifications)
+			notifications.POST("/:id/read", h.MarkNotificationRead)
+		}
+
+		// 通報（レシピ・コメント・ユーザー）
+		v1.POST("/reports", requireAuth, h.CreateReport)
+
+		// 管理者（JWT の app_metadata.role が admin）
+		admin := v1.Group("/admin", auth.RequireRole(auth.RoleAdmin))
+		{
+			admin.GET("/reports", h.GetReports)
+			admin.PUT("/reports/:id", h.UpdateReport)
+			admin.POST("/recipes/:id/hide", h.HideRecipe)
+			admin.DELETE("/recipes/:id/hide", h.UnhideRecipe)
+			admin.POST("/comments/:id/hide", h.HideComment)
+			admin.DELETE("/comments/:id/hide", h.UnhideComment)
+			admin.POST("/users/:id/ban", h.BanUser)
+			admin.DELETE("/users/:id/ban", h.UnbanUser)
+		}
+	}
+}
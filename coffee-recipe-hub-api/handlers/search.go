@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ========== Search Handlers ==========
+
+// Search レシピと豆の全文検索
+// クエリ: q（必須）, type（recipe / bean、カンマ区切り）, limit, cursor
+// 未認証の場合は公開レシピのみ、認証済みなら自分のレシピと豆も対象
+func (h *Handler) Search(c *gin.Context) {
+	text := strings.TrimSpace(c.Query("q"))
+	if text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+
+	var types []models.SearchResultType
+	if s := c.Query("type"); s != "" {
+		for _, t := range strings.Split(s, ",") {
+			switch models.SearchResultType(t) {
+			case models.SearchResultRecipe, models.SearchResultBean:
+				types = append(types, models.SearchResultType(t))
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"error": "type must be 'recipe' or 'bean'"})
+				return
+			}
+		}
+	}
+
+	limit, ok := parseIntQuery(c, "limit", 1, repository.MaxLimit)
+	if !ok {
+		return
+	}
+	if limit == 0 {
+		limit = repository.DefaultLimit
+	}
+
+	page, err := h.store.Search.Search(c.Request.Context(), c.GetString("userID"), repository.SearchQuery{
+		Text:   text,
+		Types:  types,
+		Limit:  limit,
+		Cursor: c.Query("cursor"),
+	})
+	if err != nil {
+		if err == repository.ErrInvalidCursor {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		respondInternalError(c, err)
+		return
+	}
+	now := time.Now()
+	for _, result := range page.Items {
+		if result.Bean != nil {
+			h.freshness.Annotate(result.Bean, now)
+		}
+		if result.Recipe != nil {
+			brewing.AnnotateWater(result.Recipe)
+		}
+	}
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,44 @@
+package handlers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSearchHighlightEscapesHTML(t *testing.T) {
+	s := newTestServer(t)
+	created(t, s.as(alice).do(http.MethodPost, "/recipes", recipeBody(`<img src=x onerror="alert(1)"> Kenya`, true)), http.StatusCreated)
+
+	rec := s.anonymous().do(http.MethodGet, "/search?q=kenya", nil)
+	expect(t, rec, http.StatusOK)
+	var page struct {
+		Items []models.SearchResult `json:"items"`
+	}
+	decode(t, rec, &page)
+	if len(page.Items) != 1 {
+		t.Fatalf("search returned %d results, want 1", len(page.Items))
+	}
+	got := page.Items[0].Highlight
+	if strings.Contains(got, "<img") {
+		t.Errorf("highlight %q contains unescaped HTML", got)
+	}
+	if !strings.Contains(got, "&lt;img") || !strings.Contains(got, "<mark>Kenya</mark>") {
+		t.Errorf("highlight = %q, want escaped text with the match marked", got)
+	}
+}
+
+func TestSearchRejectsCursorBeyondMaxOffset(t *testing.T) {
+	s := newTestServer(t)
+	s.as(alice).createRecipe(true)
+
+	cursor := repository.EncodeCursor(gin.H{"q": "test", "o": repository.MaxSearchOffset + 1})
+	expect(t, s.anonymous().do(http.MethodGet, "/search?q=test&cursor="+cursor, nil), http.StatusBadRequest)
+
+	cursor = repository.EncodeCursor(gin.H{"q": "test", "o": repository.MaxSearchOffset})
+	expect(t, s.anonymous().do(http.MethodGet, "/search?q=test&cursor="+cursor, nil), http.StatusOK)
+}
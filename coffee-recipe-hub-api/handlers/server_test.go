@@ -0,0 +1,193 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/auth"
+	"github.com/coffee-recipe-hub/api/freshness"
+	"github.com/coffee-recipe-hub/api/handlers"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/coffee-recipe-hub/api/repository/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// テスト用のユーザーID
+const (
+	alice = "00000000-0000-0000-0000-00000000000a"
+	bob   = "00000000-0000-0000-0000-00000000000b"
+	carol = "00000000-0000-0000-0000-00000000000c"
+	admin = "00000000-0000-0000-0000-0000000000ad"
+)
+
+const testJWTSecret = "test-secret"
+
+// testServer インメモリのリポジトリと本番と同じルート・認証ミドルウェアで組み立てたサーバー
+type testServer struct {
+	t      *testing.T
+	router *gin.Engine
+	store  *repository.Store
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	return newTestServerWith(t, memory.New())
+}
+
+// newTestServerWith store を使うサーバー（リポジトリの一部を差し替えるテスト用）
+func newTestServerWith(t *testing.T, store *repository.Store) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(auth.Middleware(auth.Config{JWTSecret: testJWTSecret}))
+	handlers.New(store, freshness.NewModel()).RegisterRoutes(r)
+	return &testServer{t: t, router: r, store: store}
+}
+
+// client 1人のユーザーとしてリクエストするクライアント（token が空なら未認証）
+type client struct {
+	s     *testServer
+	token string
+}
+
+// as userID としてリクエストする
+func (s *testServer) as(userID string) client {
+	return client{s: s, token: s.sign(userID, "")}
+}
+
+// asAdmin userID の管理者としてリクエストする
+func (s *testServer) asAdmin(userID string) client {
+	return client{s: s, token: s.sign(userID, auth.RoleAdmin)}
+}
+
+// anonymous 未認証でリクエストする
+func (s *testServer) anonymous() client {
+	return client{s: s}
+}
+
+// sign Supabase と同じ形のHS256トークンを作成（role は app_metadata.role）
+func (s *testServer) sign(userID, role string) string {
+	s.t.Helper()
+	claims := jwt.MapClaims{
+		"sub": userID,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	if role != "" {
+		claims["app_metadata"] = map[string]interface{}{"role": role}
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		s.t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// do body を JSON にしてリクエストを送る
+func (c client) do(method, path string, body interface{}) *httptest.ResponseRecorder {
+	c.s.t.Helper()
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			c.s.t.Fatalf("failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	req := httptest.NewRequest(method, "/api/v1"+path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	rec := httptest.NewRecorder()
+	c.s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// expect ステータスコードを検証して、want 以外ならレスポンスを出力して失敗させる
+func expect(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, want, rec.Body.String())
+	}
+}
+
+// decode レスポンスを v に読み込む
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+	}
+}
+
+// created want のステータスを検証して id を返す
+func created(t *testing.T, rec *httptest.ResponseRecorder, want int) string {
+	t.Helper()
+	expect(t, rec, want)
+	var body struct {
+		ID string `json:"id"`
+	}
+	decode(t, rec, &body)
+	if body.ID == "" {
+		t.Fatalf("response has no id: %s", rec.Body.String())
+	}
+	return body.ID
+}
+
+// createBean 在庫 200g の豆を作成
+func (c client) createBean() string {
+	c.s.t.Helper()
+	return created(c.s.t, c.do(http.MethodPost, "/beans", gin.H{
+		"name": "Ethiopia Guji", "roastLevel": "LIGHT", "stockGrams": 200,
+	}), http.StatusCreated)
+}
+
+// createRecipe V60 のレシピを作成
+func (c client) createRecipe(isPublic bool) string {
+	c.s.t.Helper()
+	return created(c.s.t, c.do(http.MethodPost, "/recipes", recipeBody("Morning V60", isPublic)), http.StatusCreated)
+}
+
+func recipeBody(title string, isPublic bool) gin.H {
+	return gin.H{
+		"title": title, "equipment": "V60", "coffeeGrams": 15, "totalWaterMl": 250, "waterTemperature": 92,
+		"grindSize": "MEDIUM", "isPublic": isPublic,
+		"steps": []gin.H{
+			{"order": 1, "label": "Bloom", "timeSeconds": 30, "waterMl": 50},
+			{"order": 2, "label": "Pour", "timeSeconds": 90, "waterMl": 200},
+		},
+	}
+}
+
+// createBrewLog recipeID と beanID で抽出ログを作成
+func (c client) createBrewLog(recipeID, beanID string) string {
+	c.s.t.Helper()
+	return created(c.s.t, c.do(http.MethodPost, "/brew-logs", gin.H{
+		"recipeId": recipeID, "beanId": beanID, "rating": 4,
+	}), http.StatusCreated)
+}
+
+// invalidFields 400 の検証エラーを検証して、エラーのあるフィールドのパスを返す
+func invalidFields(t *testing.T, rec *httptest.ResponseRecorder) []string {
+	t.Helper()
+	expect(t, rec, http.StatusBadRequest)
+	var body struct {
+		Details []struct {
+			Field string `json:"field"`
+		} `json:"details"`
+	}
+	decode(t, rec, &body)
+	fields := make([]string, len(body.Details))
+	for i, d := range body.Details {
+		fields[i] = d.Field
+	}
+	return fields
+}
@@ -0,0 +1,33 @@
+package handlers_test
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestValidationErrorsUseJSONFieldNames(t *testing.T) {
+	s := newTestServer(t)
+	user := s.as(alice)
+	recipeID := user.createRecipe(true)
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		body   gin.H
+		want   []string
+	}{
+		{"parentId", http.MethodPost, "/recipes/" + recipeID + "/comments", gin.H{"body": "Hi", "parentId": "x"}, []string{"parentId"}},
+		{"avatarUrl", http.MethodPut, "/me", gin.H{"displayName": "Alice", "avatarUrl": "not a url"}, []string{"avatarUrl"}},
+		{"targetId", http.MethodPost, "/reports", gin.H{"targetType": "recipe", "targetId": "x", "reason": "Spam"}, []string{"targetId"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if fields := invalidFields(t, user.do(tc.method, tc.path, tc.body)); !slices.Equal(fields, tc.want) {
+				t.Errorf("invalid fields = %v, want %v", fields, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/freshness"
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/notify"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+// DefaultLowStockGrams 在庫が少ないと通知する閾値の既定値
+const DefaultLowStockGrams = 50
+
+// BeanAlerts 各ユーザーの豆について飲み頃と在庫を評価して通知する
+type BeanAlerts struct {
+	Store         *repository.Store
+	Freshness     *freshness.Model
+	Notifier      notify.Notifier
+	LowStockGrams int
+}
+
+// NewBeanAlerts NOTIFY_LOW_STOCK_GRAMS（既定50）を閾値にしたジョブを作成
+func NewBeanAlerts(store *repository.Store, model *freshness.Model, notifier notify.Notifier) *BeanAlerts {
+	lowStock := DefaultLowStockGrams
+	if s := os.Getenv("NOTIFY_LOW_STOCK_GRAMS"); s != "" {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			lowStock = n
+		} else {
+			log.Printf("⚠️  WARNING: ignoring NOTIFY_LOW_STOCK_GRAMS=%q", s)
+		}
+	}
+	return &BeanAlerts{Store: store, Freshness: model, Notifier: notifier, LowStockGrams: lowStock}
+}
+
+// Run 全ユーザーの豆を評価する
+func (a *BeanAlerts) Run(ctx context.Context) error {
+	now := time.Now()
+	opts := repository.ListOptions{Limit: repository.MaxLimit, Sort: "createdAt"}
+	for {
+		page, err := a.Store.Beans.ListAll(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for i := range page.Items {
+			if err := a.evaluate(ctx, &page.Items[i], now); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// evaluate 1つの豆の通知を作成して配信する
+// 通知は豆・焙煎日ごとに1回だけ（新しく買い足して焙煎日が変われば再度通知する）
+func (a *BeanAlerts) evaluate(ctx context.Context, bean *models.Bean, now time.Time) error {
+	a.Freshness.Annotate(bean, now)
+
+	var alerts []models.Notification
+	if bean.FreshnessState == models.FreshnessPeak {
+		alerts = append(alerts, models.Notification{
+			Type:  models.NotificationBeanPeak,
+			Title: fmt.Sprintf("%sが飲み頃になりました", bean.Name),
+			Body:  fmt.Sprintf("焙煎から%d日経ちました", *bean.DaysSinceRoast),
+		})
+	}
+	if bean.StockGrams > 0 && bean.StockGrams <= a.LowStockGrams {
+		alerts = append(alerts, models.Notification{
+			Type:  models.NotificationLowStock,
+			Title: fmt.Sprintf("%sの残りが少なくなっています", bean.Name),
+			Body:  fmt.Sprintf("残り%dgです", bean.StockGrams),
+		})
+	}
+
+	for _, n := range alerts {
+		n.UserID = bean.UserID
+		n.BeanID = bean.ID
+		n.DedupKey = fmt.Sprintf("%s:%s:%s", n.Type, bean.ID, bean.RoastDate)
+
+		created, ok, err := a.Store.Notifications.Create(ctx, n)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		// 配信に失敗しても受信箱には残るので、ログに出して次へ進む
+		if err := a.Notifier.Notify(ctx, *created); err != nil {
+			log.Printf("⚠️  WARNING: failed to deliver notification %s: %v", created.ID, err)
+		}
+	}
+	return nil
+}
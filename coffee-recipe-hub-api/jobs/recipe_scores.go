@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/ranking"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+// RecipeScores 公開レシピの人気順のスコアを集計し直す
+type RecipeScores struct {
+	Store   *repository.Store
+	Ranking ranking.Config
+}
+
+// NewRecipeScores ジョブを作成
+func NewRecipeScores(store *repository.Store, cfg ranking.Config) *RecipeScores {
+	return &RecipeScores{Store: store, Ranking: cfg}
+}
+
+// Run 現在時刻でスコアを集計する
+func (j *RecipeScores) Run(ctx context.Context) error {
+	return j.Store.Recipes.RefreshScores(ctx, j.Ranking, time.Now())
+}
@@ -0,0 +1,63 @@
+// Package jobs プロセス内で定期実行するバックグラウンドジョブ
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job 定期実行するジョブ
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler 登録したジョブをそれぞれの間隔で実行する
+// 同じジョブの実行は重ならない（前回が終わってから次の間隔を待つ）
+type Scheduler struct {
+	jobs []Job
+}
+
+// Every ジョブを登録
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Start ジョブごとにゴルーチンを起動する（起動直後に1回実行し、ctx がキャンセルされるまで繰り返す）
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce ジョブを1回実行する（エラーやパニックはログに出して次回に持ち越す）
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		log.Printf("❌ job %s failed: %v", job.Name, err)
+		return
+	}
+	log.Printf("✅ job %s finished in %s", job.Name, time.Since(start).Round(time.Millisecond))
+}
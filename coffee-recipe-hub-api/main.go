@@ -1,14 +1,22 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
-	"strings"
+	"time"
 
+	"github.com/coffee-recipe-hub/api/auth"
 	"github.com/coffee-recipe-hub/api/database"
+	"github.com/coffee-recipe-hub/api/freshness"
 	"github.com/coffee-recipe-hub/api/handlers"
+	"github.com/coffee-recipe-hub/api/jobs"
+	"github.com/coffee-recipe-hub/api/notify"
+	"github.com/coffee-recipe-hub/api/ranking"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/coffee-recipe-hub/api/repository/memory"
+	"github.com/coffee-recipe-hub/api/repository/postgres"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/joho/godotenv"
 )
 
@@ -16,11 +24,44 @@ func main() {
 	// .env ファイル読み込み
 	_ = godotenv.Load()
 
-	// データベース接続
-	if err := database.Connect(); err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	// サブコマンド: main migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	// リポジトリ初期化（STORAGE=memory でDBなしのインメモリ実装を使う）
+	var store *repository.Store
+	if os.Getenv("STORAGE") == "memory" {
+		log.Println("⚠️  WARNING: using in-memory storage, data will be lost on restart")
+		store = memory.New()
+	} else {
+		// データベース接続
+		if err := database.Connect(); err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer database.Close()
+		store = postgres.New(database.DB)
+	}
+	freshnessModel := freshness.ModelFromEnv()
+	h := handlers.New(store, freshnessModel)
+
+	// バックグラウンドジョブ（飲み頃・在庫の通知、公開レシピの人気順のスコア）
+	if os.Getenv("SCHEDULER_DISABLED") != "true" {
+		interval, err := time.ParseDuration(os.Getenv("NOTIFY_INTERVAL"))
+		if err != nil || interval <= 0 {
+			interval = time.Hour
+		}
+		alerts := jobs.NewBeanAlerts(store, freshnessModel, notify.FromEnv())
+		scheduler := &jobs.Scheduler{}
+		scheduler.Every("bean-alerts", interval, alerts.Run)
+		rankingConfig := ranking.ConfigFromEnv()
+		scores := jobs.NewRecipeScores(store, rankingConfig)
+		scheduler.Every("recipe-scores", rankingConfig.RefreshInterval, scores.Run)
+		scheduler.Start(context.Background())
 	}
-	defer database.Close()
 
 	// Ginルーター初期化
 	r := gin.Default()
@@ -37,47 +78,18 @@ func main() {
 		c.Next()
 	})
 
-	// 認証ミドルウェア
-	r.Use(AuthMiddleware())
+	// 認証ミドルウェア（トークンがあればユーザーIDを設定、拒否は RequireAuth で行う）
+	authConfig := auth.ConfigFromEnv()
+	if err := authConfig.Validate(); err != nil {
+		log.Fatalf("Invalid auth configuration: %v", err)
+	}
+	r.Use(auth.Middleware(authConfig))
 
 	// ヘルスチェック
 	r.GET("/health", handlers.HealthCheck)
 
 	// API v1 ルート
-	v1 := r.Group("/api/v1")
-	{
-		// Beans
-		beans := v1.Group("/beans")
-		{
-			beans.GET("", handlers.GetBeans)
-			beans.GET("/:id", handlers.GetBean)
-			beans.POST("", handlers.CreateBean)
-			beans.PUT("/:id", handlers.UpdateBean)
-			beans.DELETE("/:id", handlers.DeleteBean)
-		}
-
-		// Recipes
-		recipes := v1.Group("/recipes")
-		{
-			recipes.GET("", handlers.GetRecipes)
-			recipes.GET("/public", handlers.GetPublicRecipes) // 公開レシピ一覧
-			recipes.GET("/:id", handlers.GetRecipe)
-			recipes.POST("", handlers.CreateRecipe)
-			recipes.PUT("/:id", handlers.UpdateRecipe)
-			recipes.DELETE("/:id", handlers.DeleteRecipe)
-			// いいね機能
-			recipes.POST("/:id/like", handlers.LikeRecipe)
-			recipes.DELETE("/:id/like", handlers.UnlikeRecipe)
-			recipes.GET("/:id/like", handlers.CheckLikeStatus)
-		}
-
-		// BrewLogs
-		brewLogs := v1.Group("/brew-logs")
-		{
-			brewLogs.GET("", handlers.GetBrewLogs)
-			brewLogs.POST("", handlers.CreateBrewLog)
-		}
-	}
+	h.RegisterRoutes(r)
 
 	// ポート設定
 	port := os.Getenv("PORT")
@@ -91,59 +103,3 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
-
-// AuthMiddleware JWTトークンからユーザーIDを抽出するミドルウェア
-func AuthMiddleware() gin.HandlerFunc {
-	jwtSecret := os.Getenv("SUPABASE_JWT_SECRET")
-
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.Next()
-			return
-		}
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.Next()
-			return
-		}
-
-		tokenString := parts[1]
-
-		var token *jwt.Token
-		var err error
-
-		if jwtSecret != "" {
-			// 本番モード: JWT署名を検証
-			token, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, jwt.ErrSignatureInvalid
-				}
-				return []byte(jwtSecret), nil
-			})
-			if err != nil {
-				log.Printf("Failed to verify token: %v", err)
-				c.Next()
-				return
-			}
-		} else {
-			// 開発モード: 署名検証なし（警告ログ）
-			log.Println("⚠️  WARNING: SUPABASE_JWT_SECRET not set, skipping signature verification")
-			token, _, err = new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
-			if err != nil {
-				log.Printf("Failed to parse token: %v", err)
-				c.Next()
-				return
-			}
-		}
-
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			if sub, ok := claims["sub"].(string); ok {
-				c.Set("userID", sub)
-			}
-		}
-
-		c.Next()
-	}
-}
@@ -38,15 +38,37 @@ const (
 	EquipmentOther       Equipment = "OTHER"
 )
 
-// User ユーザー
+// FreshnessState 焙煎日からの経過による豆の状態
+type FreshnessState string
+
+const (
+	FreshnessResting FreshnessState = "resting" // エイジング中
+	FreshnessPeak    FreshnessState = "peak"    // 飲み頃
+	FreshnessFading  FreshnessState = "fading"  // 飲み頃を過ぎつつある
+	FreshnessStale   FreshnessState = "stale"
+)
+
+// User ユーザー（profiles テーブル）
+// Email は認証トークンから取得し、本人にのみ返す
 type User struct {
 	ID          string    `json:"id"`
-	Email       string    `json:"email"`
+	Email       string    `json:"email,omitempty"`
 	DisplayName string    `json:"displayName"`
 	AvatarURL   string    `json:"avatarUrl,omitempty"`
+	Banned      bool      `json:"banned,omitempty"` // 利用停止中（本人と管理者にのみ返す）
 	CreatedAt   time.Time `json:"createdAt"`
 }
 
+// UserStats 公開プロフィールの集計
+type UserStats struct {
+	PublicRecipeCount int `json:"publicRecipeCount"`
+	LikesReceived     int `json:"likesReceived"` // 公開レシピへのいいねの合計
+	ForksReceived     int `json:"forksReceived"` // 公開レシピのフォーク数の合計
+	SharedBrewCount   int `json:"sharedBrewCount"`
+	FollowerCount     int `json:"followerCount"`
+	FollowingCount    int `json:"followingCount"`
+}
+
 // Bean コーヒー豆
 type Bean struct {
 	ID          string     `json:"id"`
@@ -57,59 +79,250 @@ type Bean struct {
 	RoastLevel  RoastLevel `json:"roastLevel"`
 	Process     string     `json:"process"`
 	RoastDate   string     `json:"roastDate"`
-	StockGrams  int        `json:"stockGrams"`
+	StockGrams  int        `json:"stockGrams"` // 在庫台帳の合計
 	FlavorNotes []string   `json:"flavorNotes"`
 	ImageURL    string     `json:"imageUrl,omitempty"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
+	// 飲み頃の区切り（焙煎日からの日数）の豆ごとの上書き。未指定なら焙煎度の既定値
+	RestDays    *int `json:"restDays,omitempty"`
+	PeakEndDays *int `json:"peakEndDays,omitempty"`
+	StaleDays   *int `json:"staleDays,omitempty"`
+	// 焙煎日から算出（焙煎日が未設定なら省略）
+	DaysSinceRoast *int           `json:"daysSinceRoast,omitempty"`
+	FreshnessState FreshnessState `json:"freshnessState,omitempty"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
 }
 
+// StepWaterMode RecipeStep.WaterMl の意味
+type StepWaterMode string
+
+const (
+	StepWaterPerPour    StepWaterMode = "per_pour"   // そのステップで注ぐ量
+	StepWaterCumulative StepWaterMode = "cumulative" // そのステップ終了時点のスケールの目標値（0は注がないステップ）
+)
+
 // RecipeStep レシピステップ
 type RecipeStep struct {
 	Order       int    `json:"order"`
 	Label       string `json:"label"`
 	TimeSeconds int    `json:"timeSeconds"`
-	WaterMl     int    `json:"waterMl"`
+	WaterMl     int    `json:"waterMl"` // レシピの StepWaterMode に従う
 	Notes       string `json:"notes,omitempty"`
+	// レスポンス時に WaterMl から算出（保存値は使わない）
+	CumulativeWaterMl int `json:"cumulativeWaterMl"`
+	PourWaterMl       int `json:"pourWaterMl"`
 }
 
 // Recipe レシピ
 type Recipe struct {
-	ID               string       `json:"id"`
-	UserID           string       `json:"userId"`
-	AuthorName       string       `json:"authorName,omitempty"`
-	Title            string       `json:"title"`
-	Equipment        Equipment    `json:"equipment"`
-	CoffeeGrams      float64      `json:"coffeeGrams"`
-	TotalWaterMl     int          `json:"totalWaterMl"`
-	WaterTemperature int          `json:"waterTemperature"`
-	GrindSize        GrindSize    `json:"grindSize"`
-	Steps            []RecipeStep `json:"steps"`
-	Tags             []string     `json:"tags"`
-	IsPublic         bool         `json:"isPublic"`
-	LikeCount        int          `json:"likeCount"`
-	CreatedAt        time.Time    `json:"createdAt"`
-	UpdatedAt        time.Time    `json:"updatedAt"`
+	ID               string        `json:"id"`
+	UserID           string        `json:"userId"`
+	AuthorName       string        `json:"authorName,omitempty"` // 作者のプロフィールの表示名
+	Title            string        `json:"title"`
+	Equipment        Equipment     `json:"equipment"`
+	CoffeeGrams      float64       `json:"coffeeGrams"`
+	TotalWaterMl     int           `json:"totalWaterMl"`
+	WaterTemperature int           `json:"waterTemperature"`
+	GrindSize        GrindSize     `json:"grindSize"`
+	StepWaterMode    StepWaterMode `json:"stepWaterMode"`
+	Steps            []RecipeStep  `json:"steps"`
+	Tags             []string      `json:"tags"`
+	IsPublic         bool          `json:"isPublic"`
+	LikeCount        int           `json:"likeCount"`
+	ForkCount        int           `json:"forkCount"`        // 直接のフォーク数（非公開を含む）
+	Version          int           `json:"version"`          // 現在の版（内容を変更するたびに増える）
+	Hidden           bool          `json:"hidden,omitempty"` // 管理者が非表示にした（作者本人にのみ返る）
+	// 一覧を取得したユーザーがいいね済みか（未ログインなら常に false）
+	LikedByMe bool       `json:"likedByMe"`
+	LikedAt   *time.Time `json:"likedAt,omitempty"` // いいねした日時（いいねしたレシピ一覧のみ）
+	// フォークしたレシピなら元のレシピと作者（元レシピが削除されると ForkedFromRecipeID は空になる）
+	ForkedFromRecipeID string    `json:"forkedFromRecipeId,omitempty"`
+	ForkedFromVersion  int       `json:"forkedFromVersion,omitempty"`
+	OriginalAuthorID   string    `json:"originalAuthorId,omitempty"`
+	OriginalAuthorName string    `json:"originalAuthorName,omitempty"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// RecipeForkNode フォークの系統の1件
+type RecipeForkNode struct {
+	ID                string           `json:"id"`
+	UserID            string           `json:"userId"`
+	AuthorName        string           `json:"authorName,omitempty"`
+	Title             string           `json:"title"`
+	IsPublic          bool             `json:"isPublic"`
+	LikeCount         int              `json:"likeCount"`
+	ForkCount         int              `json:"forkCount"`
+	ForkedFromVersion int              `json:"forkedFromVersion,omitempty"`
+	CreatedAt         time.Time        `json:"createdAt"`
+	Forks             []RecipeForkNode `json:"forks"`
+}
+
+// RecipeForkTree レシピから派生したフォークの系統（閲覧できるものだけ）
+type RecipeForkTree struct {
+	RecipeID  string           `json:"recipeId"`
+	ForkCount int              `json:"forkCount"` // 直接のフォーク数（非公開を含む）
+	Forks     []RecipeForkNode `json:"forks"`
+}
+
+// RecipeRevision レシピのある版の内容（作成後は変更しない）
+// 公開設定・作者名・いいね数は版に含めない
+type RecipeRevision struct {
+	ID               string        `json:"id"`
+	RecipeID         string        `json:"recipeId"`
+	Version          int           `json:"version"`
+	Title            string        `json:"title"`
+	Equipment        Equipment     `json:"equipment"`
+	CoffeeGrams      float64       `json:"coffeeGrams"`
+	TotalWaterMl     int           `json:"totalWaterMl"`
+	WaterTemperature int           `json:"waterTemperature"`
+	GrindSize        GrindSize     `json:"grindSize"`
+	StepWaterMode    StepWaterMode `json:"stepWaterMode"`
+	Steps            []RecipeStep  `json:"steps"`
+	Tags             []string      `json:"tags"`
+	RestoredFrom     *int          `json:"restoredFrom,omitempty"` // 過去の版を復元して作った版なら復元元
+	CreatedAt        time.Time     `json:"createdAt"`
+}
+
+// RecipeFieldChange 版の間で変わった項目
+type RecipeFieldChange struct {
+	Field string      `json:"field"` // JSONの項目名
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// StepChangeType ステップの変更の種類
+type StepChangeType string
+
+const (
+	StepAdded    StepChangeType = "added"
+	StepRemoved  StepChangeType = "removed"
+	StepModified StepChangeType = "modified"
+)
+
+// RecipeStepChange 版の間で変わったステップ（order で対応付ける）
+type RecipeStepChange struct {
+	Order  int            `json:"order"`
+	Change StepChangeType `json:"change"`
+	From   *RecipeStep    `json:"from,omitempty"`
+	To     *RecipeStep    `json:"to,omitempty"`
+	Fields []string       `json:"fields,omitempty"` // modified のとき変わった項目
+}
+
+// RecipeDiff 2つの版の差分
+type RecipeDiff struct {
+	RecipeID    string              `json:"recipeId"`
+	FromVersion int                 `json:"fromVersion"`
+	ToVersion   int                 `json:"toVersion"`
+	Fields      []RecipeFieldChange `json:"fields"`
+	Steps       []RecipeStepChange  `json:"steps"`
+}
+
+// ScaledRecipeStep 分量を変えたレシピのステップ
+type ScaledRecipeStep struct {
+	RecipeStep
+	OriginalWaterMl int    `json:"originalWaterMl"`
+	Rounding        string `json:"rounding"` // nearest_1ml, nearest_5ml, match_total
+}
+
+// ScaleWarningCode 分量を変えたときの警告の種類
+type ScaleWarningCode string
+
+const (
+	ScaleWarningWaterCapacity  ScaleWarningCode = "water_exceeds_capacity"
+	ScaleWarningCoffeeCapacity ScaleWarningCode = "coffee_exceeds_capacity"
+)
+
+// ScaleWarning 分量を変えたときの警告
+type ScaleWarning struct {
+	Code    ScaleWarningCode `json:"code"`
+	Message string           `json:"message"`
+	Limit   float64          `json:"limit"`
+}
+
+// ScaledRecipe 豆量・湯量を変えて計算し直したレシピ
+type ScaledRecipe struct {
+	RecipeID             string             `json:"recipeId"`
+	Title                string             `json:"title"`
+	Equipment            Equipment          `json:"equipment"`
+	CoffeeGrams          float64            `json:"coffeeGrams"`
+	TotalWaterMl         int                `json:"totalWaterMl"`
+	Ratio                float64            `json:"ratio"` // 湯量 / 豆量
+	WaterTemperature     int                `json:"waterTemperature"`
+	GrindSize            GrindSize          `json:"grindSize"`
+	StepWaterMode        StepWaterMode      `json:"stepWaterMode"`
+	ScaleFactor          float64            `json:"scaleFactor"` // 湯量の倍率
+	OriginalCoffeeGrams  float64            `json:"originalCoffeeGrams"`
+	OriginalTotalWaterMl int                `json:"originalTotalWaterMl"`
+	Steps                []ScaledRecipeStep `json:"steps"`
+	Warnings             []ScaleWarning     `json:"warnings"`
 }
 
 // TasteNote 味の評価
 type TasteNote struct {
 	Aspect string `json:"aspect"` // acidity, bitterness, sweetness, body, aftertaste
-	Score  int    `json:"score"`  // 1-5
+	Score  int    `json:"score" binding:"min=1,max=5"`
 }
 
 // BrewLog 抽出ログ
 type BrewLog struct {
-	ID             string      `json:"id"`
-	UserID         string      `json:"userId"`
-	RecipeID       string      `json:"recipeId"`
-	BeanID         string      `json:"beanId"`
-	BrewDate       time.Time   `json:"brewDate"`
-	ActualDuration int         `json:"actualDuration"` // 秒
-	Rating         int         `json:"rating"`         // 1-5
-	TasteNotes     []TasteNote `json:"tasteNotes"`
-	Memo           string      `json:"memo,omitempty"`
-	CreatedAt      time.Time   `json:"createdAt"`
+	ID             string    `json:"id"`
+	UserID         string    `json:"userId"`
+	RecipeID       string    `json:"recipeId"`
+	RecipeVersion  int       `json:"recipeVersion,omitempty"` // 抽出したときのレシピの版
+	BeanID         string    `json:"beanId"`
+	BrewDate       time.Time `json:"brewDate"`
+	ActualDuration int       `json:"actualDuration"` // 秒
+	// 実際の抽出条件（省略時はレシピの値）
+	CoffeeGrams      float64     `json:"coffeeGrams"`
+	WaterMl          int         `json:"waterMl"`
+	WaterTemperature int         `json:"waterTemperature"`
+	GrindSetting     string      `json:"grindSetting"`
+	YieldGrams       float64     `json:"yieldGrams,omitempty"` // 抽出液の重さ（未計量なら0）
+	Rating           int         `json:"rating"`               // 1-5
+	TasteNotes       []TasteNote `json:"tasteNotes"`
+	Memo             string      `json:"memo,omitempty"`
+	// StockDeductedGrams このログで豆の在庫から差し引いた量（削除・変更時に戻す）
+	StockDeductedGrams int `json:"stockDeductedGrams"`
+	// レシピへの「Brewed it!」として公開した日時（非公開なら省略）
+	SharedAt      *time.Time `json:"sharedAt,omitempty"`
+	Modifications string     `json:"modifications,omitempty"` // レシピから変えた点
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// BrewPost レシピへの「Brewed it!」として公開された抽出ログ
+// 豆・在庫など公開しない項目は含めない
+type BrewPost struct {
+	ID               string      `json:"id"` // 抽出ログのID
+	RecipeID         string      `json:"recipeId"`
+	RecipeVersion    int         `json:"recipeVersion,omitempty"`
+	UserID           string      `json:"userId"`
+	BrewDate         time.Time   `json:"brewDate"`
+	ActualDuration   int         `json:"actualDuration"`
+	CoffeeGrams      float64     `json:"coffeeGrams"`
+	WaterMl          int         `json:"waterMl"`
+	WaterTemperature int         `json:"waterTemperature"`
+	GrindSetting     string      `json:"grindSetting"`
+	YieldGrams       float64     `json:"yieldGrams,omitempty"`
+	Rating           int         `json:"rating"`
+	TasteNotes       []TasteNote `json:"tasteNotes"`
+	Memo             string      `json:"memo,omitempty"`
+	Modifications    string      `json:"modifications,omitempty"`
+	SharedAt         time.Time   `json:"sharedAt"`
+}
+
+// TasteAverage 味の評価項目ごとの平均
+type TasteAverage struct {
+	Aspect  string  `json:"aspect"`
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// BrewSummary レシピに公開された抽出ログの集計
+type BrewSummary struct {
+	BrewCount     int            `json:"brewCount"`
+	AverageRating float64        `json:"averageRating"` // 公開ログがなければ0
+	TasteProfile  []TasteAverage `json:"tasteProfile"`  // 項目名順
 }
 
 // CreateBeanRequest 豆作成リクエスト
@@ -119,31 +332,253 @@ type CreateBeanRequest struct {
 	Origin      string     `json:"origin"`
 	RoastLevel  RoastLevel `json:"roastLevel"`
 	Process     string     `json:"process"`
-	RoastDate   string     `json:"roastDate"`
-	StockGrams  int        `json:"stockGrams"`
+	RoastDate   string     `json:"roastDate" binding:"omitempty,datetime=2006-01-02"`
+	StockGrams  int        `json:"stockGrams" binding:"min=0"` // 初回購入分として台帳に記録する
 	FlavorNotes []string   `json:"flavorNotes"`
+	// 飲み頃の区切りの上書き（省略時は焙煎度の既定値）
+	RestDays    *int `json:"restDays" binding:"omitempty,min=0,max=365"`
+	PeakEndDays *int `json:"peakEndDays" binding:"omitempty,min=0,max=365"`
+	StaleDays   *int `json:"staleDays" binding:"omitempty,min=0,max=365"`
+}
+
+// UpdateBeanRequest 豆更新リクエスト
+// StockGrams を指定すると現在の在庫との差分を adjustment として台帳に記録する
+type UpdateBeanRequest struct {
+	Name        string     `json:"name" binding:"required"`
+	RoasterName string     `json:"roasterName"`
+	Origin      string     `json:"origin"`
+	RoastLevel  RoastLevel `json:"roastLevel"`
+	Process     string     `json:"process"`
+	RoastDate   string     `json:"roastDate" binding:"omitempty,datetime=2006-01-02"`
+	StockGrams  *int       `json:"stockGrams" binding:"omitempty,min=0"`
+	FlavorNotes []string   `json:"flavorNotes"`
+	// 飲み頃の区切りの上書き（省略時は焙煎度の既定値）
+	RestDays    *int `json:"restDays" binding:"omitempty,min=0,max=365"`
+	PeakEndDays *int `json:"peakEndDays" binding:"omitempty,min=0,max=365"`
+	StaleDays   *int `json:"staleDays" binding:"omitempty,min=0,max=365"`
+}
+
+// StockMovementType 在庫の増減の種類
+type StockMovementType string
+
+const (
+	StockMovementPurchase   StockMovementType = "purchase"
+	StockMovementBrew       StockMovementType = "brew"
+	StockMovementAdjustment StockMovementType = "adjustment"
+	StockMovementWaste      StockMovementType = "waste"
+)
+
+// StockMovement 豆の在庫台帳の1行
+type StockMovement struct {
+	ID           string            `json:"id"`
+	BeanID       string            `json:"beanId"`
+	UserID       string            `json:"userId"`
+	Type         StockMovementType `json:"type"`
+	Grams        int               `json:"grams"`        // 増減量（減少は負）
+	BalanceAfter int               `json:"balanceAfter"` // この行を反映した後の在庫
+	BrewLogID    string            `json:"brewLogId,omitempty"`
+	Note         string            `json:"note,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt"`
+}
+
+// CreateStockMovementRequest 在庫の手動記録リクエスト
+// purchase / waste の grams は正の量、adjustment は符号付きの増減量
+// brew は抽出ログからのみ記録する
+type CreateStockMovementRequest struct {
+	Type  StockMovementType `json:"type" binding:"required,oneof=purchase adjustment waste"`
+	Grams int               `json:"grams" binding:"required"`
+	Note  string            `json:"note"`
 }
 
 // CreateRecipeRequest レシピ作成リクエスト
 type CreateRecipeRequest struct {
-	Title            string       `json:"title" binding:"required"`
-	AuthorName       string       `json:"authorName"`
-	Equipment        Equipment    `json:"equipment" binding:"required"`
-	CoffeeGrams      float64      `json:"coffeeGrams" binding:"required"`
-	TotalWaterMl     int          `json:"totalWaterMl" binding:"required"`
-	WaterTemperature int          `json:"waterTemperature"`
-	GrindSize        GrindSize    `json:"grindSize"`
-	Steps            []RecipeStep `json:"steps"`
-	Tags             []string     `json:"tags"`
-	IsPublic         bool         `json:"isPublic"`
+	Title            string    `json:"title" binding:"required"`
+	Equipment        Equipment `json:"equipment" binding:"required"`
+	CoffeeGrams      float64   `json:"coffeeGrams" binding:"required"`
+	TotalWaterMl     int       `json:"totalWaterMl" binding:"required"`
+	WaterTemperature int       `json:"waterTemperature"`
+	GrindSize        GrindSize `json:"grindSize"`
+	// StepWaterMode 省略時は per_pour（更新では今のレシピの値）
+	StepWaterMode StepWaterMode `json:"stepWaterMode" binding:"omitempty,oneof=per_pour cumulative"`
+	Steps         []RecipeStep  `json:"steps"`
+	Tags          []string      `json:"tags"`
+	IsPublic      bool          `json:"isPublic"`
+}
+
+// UpdateProfileRequest プロフィール更新リクエスト
+type UpdateProfileRequest struct {
+	DisplayName string `json:"displayName" binding:"required,max=100"`
+	AvatarURL   string `json:"avatarUrl" binding:"omitempty,url,max=2048"`
+}
+
+// ShareBrewLogRequest 抽出ログ公開リクエスト（本文は省略可）
+type ShareBrewLogRequest struct {
+	Modifications string `json:"modifications" binding:"max=1000"`
+}
+
+// CreateCommentRequest コメント投稿リクエスト
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required,max=2000"`
+	ParentID string `json:"parentId" binding:"omitempty,uuid"` // 返信先（トップレベルのコメントのみ）
+}
+
+// UpdateCommentRequest コメント編集リクエスト
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+// ReportCommentRequest コメント通報リクエスト
+type ReportCommentRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// CreateReportRequest 通報リクエスト
+type CreateReportRequest struct {
+	TargetType ReportTargetType `json:"targetType" binding:"required,oneof=recipe comment user"`
+	TargetID   string           `json:"targetId" binding:"required,uuid"`
+	Reason     string           `json:"reason" binding:"required,max=500"`
+}
+
+// UpdateReportRequest 通報の対応状況の更新リクエスト（管理者用）
+type UpdateReportRequest struct {
+	Status ReportStatus `json:"status" binding:"required,oneof=resolved dismissed"`
+}
+
+// ForkRecipeRequest フォーク作成リクエスト（本文は省略可）
+type ForkRecipeRequest struct {
+	Title string `json:"title" binding:"max=255"` // 省略時は元のタイトル
 }
 
 // CreateBrewLogRequest 抽出ログ作成リクエスト
 type CreateBrewLogRequest struct {
-	RecipeID       string      `json:"recipeId" binding:"required"`
-	BeanID         string      `json:"beanId" binding:"required"`
-	ActualDuration int         `json:"actualDuration"`
-	Rating         int         `json:"rating" binding:"required,min=1,max=5"`
-	TasteNotes     []TasteNote `json:"tasteNotes"`
-	Memo           string      `json:"memo"`
+	RecipeID       string `json:"recipeId" binding:"required"`
+	RecipeVersion  int    `json:"recipeVersion" binding:"omitempty,min=1"` // 省略時はレシピの現在の版
+	BeanID         string `json:"beanId" binding:"required"`
+	ActualDuration int    `json:"actualDuration" binding:"omitempty,min=0"` // 秒
+	// 実際の抽出条件（省略時はレシピの値で補完する）
+	CoffeeGrams      float64     `json:"coffeeGrams" binding:"omitempty,gt=0,max=1000"`
+	WaterMl          int         `json:"waterMl" binding:"omitempty,gt=0,max=10000"`
+	WaterTemperature int         `json:"waterTemperature" binding:"omitempty,min=0,max=100"`
+	GrindSetting     string      `json:"grindSetting" binding:"max=50"` // 例: "Comandante 24 clicks"
+	YieldGrams       float64     `json:"yieldGrams" binding:"omitempty,gt=0,max=10000"`
+	Rating           int         `json:"rating" binding:"required,min=1,max=5"`
+	TasteNotes       []TasteNote `json:"tasteNotes" binding:"omitempty,dive"`
+	Memo             string      `json:"memo" binding:"max=2000"`
+}
+
+// NotificationType 通知の種類
+type NotificationType string
+
+const (
+	NotificationBeanPeak NotificationType = "bean_peak" // 豆が飲み頃に入った
+	NotificationLowStock NotificationType = "low_stock" // 豆の残りが少ない
+)
+
+// Notification 通知
+type Notification struct {
+	ID     string           `json:"id"`
+	UserID string           `json:"userId"`
+	Type   NotificationType `json:"type"`
+	BeanID string           `json:"beanId,omitempty"`
+	Title  string           `json:"title"`
+	Body   string           `json:"body"`
+	// DedupKey 同じ通知を2回送らないためのキー（ユーザーごとに一意）
+	DedupKey  string     `json:"-"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// SearchResultType 検索結果の種類
+type SearchResultType string
+
+const (
+	SearchResultRecipe SearchResultType = "recipe"
+	SearchResultBean   SearchResultType = "bean"
+)
+
+// SearchResult 全文検索の結果1件
+type SearchResult struct {
+	Type      SearchResultType `json:"type"`
+	ID        string           `json:"id"`
+	Title     string           `json:"title"`
+	Highlight string           `json:"highlight"` // 一致箇所を <mark> で囲んだ抜粋（本文は HTML エスケープ済み）
+	Rank      float64          `json:"rank"`
+	Recipe    *Recipe          `json:"recipe,omitempty"`
+	Bean      *Bean            `json:"bean,omitempty"`
+}
+
+// LikeStatus いいね・取り消し後の状態
+type LikeStatus struct {
+	Liked     bool `json:"liked"`
+	LikeCount int  `json:"likeCount"`
+}
+
+// FollowUser フォロー・フォロワー一覧の1件
+type FollowUser struct {
+	UserID     string    `json:"userId"`
+	FollowedAt time.Time `json:"followedAt"`
+}
+
+// FeedItemType フィードの項目の種類
+type FeedItemType string
+
+const (
+	FeedItemRecipe FeedItemType = "recipe" // 公開レシピ
+	FeedItemBrew   FeedItemType = "brew"   // 「Brewed it!」
+)
+
+// FeedItem フィードの1件（フォロー中のユーザーの公開レシピ・Brewed it!）
+type FeedItem struct {
+	Type      FeedItemType `json:"type"`
+	ID        string       `json:"id"`
+	UserID    string       `json:"userId"`
+	CreatedAt time.Time    `json:"createdAt"` // レシピは作成日時、Brewed it! は公開日時
+	Recipe    *Recipe      `json:"recipe,omitempty"`
+	Brew      *BrewPost    `json:"brew,omitempty"`
+}
+
+// Comment レシピへのコメント（返信は1階層まで）
+// 削除されたコメントは返信が残っている場合のみ、本文と投稿者を空にした tombstone（Deleted）として返す
+type Comment struct {
+	ID         string     `json:"id"`
+	RecipeID   string     `json:"recipeId"`
+	ParentID   string     `json:"parentId,omitempty"`
+	UserID     string     `json:"userId,omitempty"`
+	AuthorName string     `json:"authorName,omitempty"` // 投稿者のプロフィールの表示名
+	Body       string     `json:"body"`
+	Deleted    bool       `json:"deleted"`
+	EditedAt   *time.Time `json:"editedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Replies    []Comment  `json:"replies,omitempty"` // トップレベルのコメントのみ（古い順）
+}
+
+// ReportTargetType 通報の対象の種類
+type ReportTargetType string
+
+const (
+	ReportTargetRecipe  ReportTargetType = "recipe"
+	ReportTargetComment ReportTargetType = "comment"
+	ReportTargetUser    ReportTargetType = "user"
+)
+
+// ReportStatus 通報の対応状況
+type ReportStatus string
+
+const (
+	ReportPending   ReportStatus = "pending"   // レビュー待ち
+	ReportResolved  ReportStatus = "resolved"  // 対応済み（非表示・利用停止を含む）
+	ReportDismissed ReportStatus = "dismissed" // 問題なし
+)
+
+// Report 通報
+type Report struct {
+	ID         string           `json:"id"`
+	TargetType ReportTargetType `json:"targetType"`
+	TargetID   string           `json:"targetId"`
+	ReporterID string           `json:"reporterId"`
+	Reason     string           `json:"reason"`
+	Status     ReportStatus     `json:"status"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	ResolvedAt *time.Time       `json:"resolvedAt,omitempty"`
+	ResolvedBy string           `json:"resolvedBy,omitempty"`
 }
@@ -0,0 +1,99 @@
+// Package notify 通知の配信先（Webhook・Expo Push・ログ）
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+// Notifier 通知を外部に配信する
+// 受信箱（notifications テーブル）への保存は呼び出し側で済ませてから呼ぶ
+type Notifier interface {
+	Notify(ctx context.Context, n models.Notification) error
+}
+
+// FromEnv 環境変数で有効にした配信先をまとめる（ログへの出力は常に行う）
+// NOTIFY_WEBHOOK_URL: 通知をJSONでPOSTするURL
+// NOTIFY_EXPO_PUSH=true: Expo Push（スタブ）
+func FromEnv() Notifier {
+	notifiers := Multi{LogNotifier{}}
+	if url := os.Getenv("NOTIFY_WEBHOOK_URL"); url != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(url))
+	}
+	if os.Getenv("NOTIFY_EXPO_PUSH") == "true" {
+		notifiers = append(notifiers, ExpoPushNotifier{})
+	}
+	return notifiers
+}
+
+// Multi 複数の配信先にすべて配信する（1つが失敗しても残りには配信する）
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, n models.Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogNotifier 通知をサーバーログに出力する
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, n models.Notification) error {
+	log.Printf("🔔 notification %s to user %s: %s", n.Type, n.UserID, n.Title)
+	return nil
+}
+
+// WebhookNotifier 通知をJSONでPOSTする
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookNotifier タイムアウト付きの WebhookNotifier を作成
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, n models.Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ExpoPushNotifier Expo Push 通知のスタブ
+// 端末のプッシュトークンを保存する仕組みができるまでは送信内容をログに出すだけ
+type ExpoPushNotifier struct{}
+
+func (ExpoPushNotifier) Notify(ctx context.Context, n models.Notification) error {
+	log.Printf("📱 expo push (stub) to user %s: %s - %s", n.UserID, n.Title, n.Body)
+	return nil
+}
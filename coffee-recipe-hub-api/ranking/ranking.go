@@ -0,0 +1,123 @@
+// Package ranking 公開レシピの人気順（trending / top）のスコアを計算する
+package ranking
+
+import (
+	"log"
+	"math"
+	"os"
+	"time"
+)
+
+// イベントごとの重み（フォークや「Brewed it!」はいいねより手間がかかる分だけ重くする）
+const (
+	LikeWeight = 1.0
+	ForkWeight = 3.0
+	BrewWeight = 2.0
+)
+
+// Config スコアを数える期間
+//
+//	trending: TrendingWindow 以内のイベントを HalfLife ごとに半減させて合計
+//	top:      TopWindow 以内のイベントの重みをそのまま合計（0 なら全期間）
+type Config struct {
+	TrendingWindow time.Duration
+	HalfLife       time.Duration
+	TopWindow      time.Duration
+	// スコアを集計し直す間隔
+	RefreshInterval time.Duration
+}
+
+// DefaultConfig 既定の期間
+func DefaultConfig() Config {
+	return Config{
+		TrendingWindow:  7 * 24 * time.Hour,
+		HalfLife:        48 * time.Hour,
+		TopWindow:       30 * 24 * time.Hour,
+		RefreshInterval: 10 * time.Minute,
+	}
+}
+
+// ConfigFromEnv 環境変数で期間を上書きした設定を作成
+// RANKING_TRENDING_WINDOW=168h のように time.ParseDuration の形式で指定する（不正な値は警告して既定値を使う）
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	override := func(name string, d *time.Duration, allowZero bool) {
+		s := os.Getenv(name)
+		if s == "" {
+			return
+		}
+		v, err := time.ParseDuration(s)
+		if err != nil || v < 0 || (v == 0 && !allowZero) {
+			log.Printf("⚠️  WARNING: ignoring %s=%q", name, s)
+			return
+		}
+		*d = v
+	}
+	override("RANKING_TRENDING_WINDOW", &cfg.TrendingWindow, false)
+	override("RANKING_HALF_LIFE", &cfg.HalfLife, false)
+	override("RANKING_TOP_WINDOW", &cfg.TopWindow, true)
+	override("RANKING_REFRESH_INTERVAL", &cfg.RefreshInterval, false)
+	return cfg
+}
+
+// TrendingSince trending に数えるイベントの下限
+func (cfg Config) TrendingSince(now time.Time) time.Time {
+	return now.Add(-cfg.TrendingWindow)
+}
+
+// TopSince top に数えるイベントの下限（全期間ならゼロ値）
+func (cfg Config) TopSince(now time.Time) time.Time {
+	if cfg.TopWindow == 0 {
+		return time.Time{}
+	}
+	return now.Add(-cfg.TopWindow)
+}
+
+// Since どちらかのスコアに数えるイベントの下限
+func (cfg Config) Since(now time.Time) time.Time {
+	trending, top := cfg.TrendingSince(now), cfg.TopSince(now)
+	if top.Before(trending) {
+		return top
+	}
+	return trending
+}
+
+// Decay 経過時間に対する減衰率（HalfLife で 0.5）
+func (cfg Config) Decay(age time.Duration) float64 {
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, age.Seconds()/cfg.HalfLife.Seconds())
+}
+
+// Event スコアに数える1件（いいね・フォーク・「Brewed it!」）
+type Event struct {
+	RecipeID string
+	Weight   float64
+	At       time.Time
+}
+
+// Score レシピごとの集計結果
+type Score struct {
+	Trending float64
+	Top      float64
+}
+
+// Scores イベントをレシピごとに集計する（期間外のイベントは数えない）
+func (cfg Config) Scores(events []Event, now time.Time) map[string]Score {
+	trendingSince, topSince := cfg.TrendingSince(now), cfg.TopSince(now)
+	scores := map[string]Score{}
+	for _, e := range events {
+		s := scores[e.RecipeID]
+		if !e.At.Before(trendingSince) {
+			s.Trending += e.Weight * cfg.Decay(now.Sub(e.At))
+		}
+		if !e.At.Before(topSince) {
+			s.Top += e.Weight
+		}
+		if s != (Score{}) {
+			scores[e.RecipeID] = s
+		}
+	}
+	return scores
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+// ErrInvalidCursor カーソルが不正（改ざん・別エンドポイントのもの等）
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	// DefaultLimit limit 未指定時の件数
+	DefaultLimit = 20
+	// MaxLimit limit の上限
+	MaxLimit = 100
+)
+
+// 一覧ごとに許可するソートキー（APIのクエリパラメータ名）
+var (
+	BeanSortKeys   = []string{"createdAt", "updatedAt", "name", "roastDate", "stockGrams"}
+	RecipeSortKeys = []string{"createdAt", "updatedAt", "title", "likeCount", "coffeeGrams"}
+	// 公開レシピは集計済みのスコアでも並べられる（new は createdAt と同じ）
+	PublicRecipeSortKeys = []string{"createdAt", "updatedAt", "title", "likeCount", "coffeeGrams", "trending", "top", "new"}
+	BrewLogSortKeys      = []string{"brewDate", "createdAt", "rating"}
+	BrewPostSortKeys     = []string{"sharedAt", "rating"}
+	// いいねしたレシピはいいねした日時順のみ
+	LikedRecipeSortKeys = []string{"likedAt"}
+	// レシピの版は版番号順のみ
+	RecipeVersionSortKeys = []string{"version"}
+	// 在庫台帳・通知・フォロー・フィードは記録順のみ
+	StockMovementSortKeys = []string{"createdAt"}
+	NotificationSortKeys  = []string{"createdAt"}
+	FollowSortKeys        = []string{"createdAt"}
+	FeedSortKeys          = []string{"createdAt"}
+	// コメントは投稿順のみ（返信は常に古い順）
+	CommentSortKeys = []string{"createdAt"}
+	ReportSortKeys  = []string{"createdAt"}
+)
+
+// ListOptions 一覧取得の共通オプション
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Desc   bool
+}
+
+// Page カーソルページングされた一覧
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// BeanFilter 豆一覧の絞り込み
+type BeanFilter struct {
+	ListOptions
+	RoastLevel models.RoastLevel
+	Origin     string // 部分一致
+	// 焙煎日の範囲
+	From *time.Time
+	To   *time.Time
+}
+
+// RecipeFilter レシピ一覧の絞り込み
+type RecipeFilter struct {
+	ListOptions
+	UserID    string // 作者
+	ViewerID  string // likedByMe を判定するユーザー（未ログインなら空）
+	Equipment models.Equipment
+	GrindSize models.GrindSize
+	Tag       string
+	// 作成日の範囲
+	From *time.Time
+	To   *time.Time
+}
+
+// ReportFilter 通報一覧の絞り込み（管理者用）
+type ReportFilter struct {
+	ListOptions
+	Status     models.ReportStatus
+	TargetType models.ReportTargetType
+}
+
+// BrewLogFilter 抽出ログ一覧の絞り込み
+type BrewLogFilter struct {
+	ListOptions
+	RecipeID  string
+	BeanID    string
+	MinRating int
+	MaxRating int
+	// 抽出日の範囲
+	From *time.Time
+	To   *time.Time
+}
+
+// NotificationFilter 通知一覧の絞り込み
+type NotificationFilter struct {
+	ListOptions
+	UnreadOnly bool
+}
+
+// EncodeCursor カーソル値を不透明な文字列に変換
+func EncodeCursor(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor EncodeCursor で作ったカーソルを復元
+func DecodeCursor(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
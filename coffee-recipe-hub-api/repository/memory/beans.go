@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type beanRepository struct {
+	*db
+}
+
+// beanSorts ソートキーごとの比較関数
+var beanSorts = map[string]func(a, b models.Bean) int{
+	"createdAt":  func(a, b models.Bean) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"updatedAt":  func(a, b models.Bean) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+	"name":       func(a, b models.Bean) int { return strings.Compare(a.Name, b.Name) },
+	"roastDate":  func(a, b models.Bean) int { return strings.Compare(a.RoastDate, b.RoastDate) },
+	"stockGrams": func(a, b models.Bean) int { return cmp.Compare(a.StockGrams, b.StockGrams) },
+}
+
+func (r *beanRepository) ListByUser(ctx context.Context, userID string, f repository.BeanFilter) (repository.Page[models.Bean], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	beans := []models.Bean{}
+	for _, bean := range r.beans {
+		if bean.UserID != userID {
+			continue
+		}
+		if f.RoastLevel != "" && bean.RoastLevel != f.RoastLevel {
+			continue
+		}
+		if f.Origin != "" && !strings.Contains(strings.ToLower(bean.Origin), strings.ToLower(f.Origin)) {
+			continue
+		}
+		if f.From != nil || f.To != nil {
+			roastDate, err := time.Parse("2006-01-02", bean.RoastDate)
+			if err != nil || !inRange(roastDate, f.From, f.To) {
+				continue
+			}
+		}
+		beans = append(beans, bean)
+	}
+	sortItems(beans, f.Desc, beanSorts[f.Sort], func(b models.Bean) string { return b.ID })
+	return paginate(beans, f.ListOptions)
+}
+
+func (r *beanRepository) ListAll(ctx context.Context, opts repository.ListOptions) (repository.Page[models.Bean], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	beans := make([]models.Bean, 0, len(r.beans))
+	for _, bean := range r.beans {
+		beans = append(beans, bean)
+	}
+	sortItems(beans, opts.Desc, beanSorts["createdAt"], func(b models.Bean) string { return b.ID })
+	return paginate(beans, opts)
+}
+
+func (r *beanRepository) Get(ctx context.Context, id string) (*models.Bean, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bean, ok := r.beans[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &bean, nil
+}
+
+func (r *beanRepository) Create(ctx context.Context, userID string, req models.CreateBeanRequest) (*models.Bean, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	bean := models.Bean{ID: newID(), UserID: userID, CreatedAt: now}
+	applyBeanRequest(&bean, models.UpdateBeanRequest{
+		Name: req.Name, RoasterName: req.RoasterName, Origin: req.Origin, RoastLevel: req.RoastLevel,
+		Process: req.Process, RoastDate: req.RoastDate, FlavorNotes: req.FlavorNotes,
+		RestDays: req.RestDays, PeakEndDays: req.PeakEndDays, StaleDays: req.StaleDays,
+	}, now)
+	r.beans[bean.ID] = bean
+	if req.StockGrams > 0 {
+		r.addMovement(models.StockMovement{
+			BeanID: bean.ID, UserID: userID, Type: models.StockMovementPurchase, Grams: req.StockGrams,
+		})
+	}
+	bean = r.beans[bean.ID]
+	return &bean, nil
+}
+
+func (r *beanRepository) Update(ctx context.Context, id, userID string, req models.UpdateBeanRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bean, ok := r.beans[id]
+	if !ok || bean.UserID != userID {
+		return repository.ErrNotFound
+	}
+	applyBeanRequest(&bean, req, time.Now())
+	r.beans[id] = bean
+	if req.StockGrams != nil {
+		if delta := *req.StockGrams - bean.StockGrams; delta != 0 {
+			r.addMovement(models.StockMovement{
+				BeanID: id, UserID: userID, Type: models.StockMovementAdjustment, Grams: delta,
+				Note: repository.StockNoteBeanEdited,
+			})
+		}
+	}
+	return nil
+}
+
+func (r *beanRepository) Delete(ctx context.Context, id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bean, ok := r.beans[id]
+	if !ok || bean.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(r.beans, id)
+	// bean_stock_movements・notifications は ON DELETE CASCADE、brew_logs.bean_id は ON DELETE SET NULL
+	r.movements = slices.DeleteFunc(r.movements, func(m models.StockMovement) bool { return m.BeanID == id })
+	for nID, n := range r.notifications {
+		if n.BeanID == id {
+			delete(r.notifications, nID)
+		}
+	}
+	for logID, log := range r.brewLogs {
+		if log.BeanID == id {
+			log.BeanID = ""
+			r.brewLogs[logID] = log
+		}
+	}
+	return nil
+}
+
+// applyBeanRequest 在庫以外の項目を反映する（在庫は台帳から求める）
+func applyBeanRequest(bean *models.Bean, req models.UpdateBeanRequest, now time.Time) {
+	bean.Name = req.Name
+	bean.RoasterName = req.RoasterName
+	bean.Origin = req.Origin
+	bean.RoastLevel = req.RoastLevel
+	bean.Process = req.Process
+	bean.RoastDate = req.RoastDate
+	bean.FlavorNotes = cloneStrings(req.FlavorNotes)
+	bean.RestDays = cloneInt(req.RestDays)
+	bean.PeakEndDays = cloneInt(req.PeakEndDays)
+	bean.StaleDays = cloneInt(req.StaleDays)
+	bean.UpdatedAt = now
+}
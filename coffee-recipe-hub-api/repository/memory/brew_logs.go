@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type brewLogRepository struct {
+	*db
+}
+
+// brewLogSorts ソートキーごとの比較関数
+var brewLogSorts = map[string]func(a, b models.BrewLog) int{
+	"brewDate":  func(a, b models.BrewLog) int { return a.BrewDate.Compare(b.BrewDate) },
+	"createdAt": func(a, b models.BrewLog) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"rating":    func(a, b models.BrewLog) int { return cmp.Compare(a.Rating, b.Rating) },
+}
+
+func (r *brewLogRepository) ListByUser(ctx context.Context, userID string, f repository.BrewLogFilter) (repository.Page[models.BrewLog], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logs := []models.BrewLog{}
+	for _, log := range r.brewLogs {
+		if log.UserID != userID {
+			continue
+		}
+		if f.RecipeID != "" && log.RecipeID != f.RecipeID {
+			continue
+		}
+		if f.BeanID != "" && log.BeanID != f.BeanID {
+			continue
+		}
+		if f.MinRating > 0 && log.Rating < f.MinRating {
+			continue
+		}
+		if f.MaxRating > 0 && log.Rating > f.MaxRating {
+			continue
+		}
+		if !inRange(log.BrewDate, f.From, f.To) {
+			continue
+		}
+		logs = append(logs, log)
+	}
+	sortItems(logs, f.Desc, brewLogSorts[f.Sort], func(l models.BrewLog) string { return l.ID })
+	return paginate(logs, f.ListOptions)
+}
+
+func (r *brewLogRepository) Get(ctx context.Context, id string) (*models.BrewLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log, ok := r.brewLogs[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &log, nil
+}
+
+func (r *brewLogRepository) Create(ctx context.Context, userID string, req models.CreateBrewLogRequest) (*models.BrewLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	log := models.BrewLog{ID: newID(), UserID: userID, BrewDate: now, CreatedAt: now}
+	applyBrewLogRequest(&log, req)
+	log.StockDeductedGrams = r.deductStock(&log)
+
+	r.brewLogs[log.ID] = log
+	return &log, nil
+}
+
+func (r *brewLogRepository) Update(ctx context.Context, id, userID string, req models.CreateBrewLogRequest) (*models.BrewLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log, ok := r.brewLogs[id]
+	if !ok || log.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+
+	// 豆か豆量が変わった場合のみ在庫を付け替える
+	moved := log.BeanID != req.BeanID || log.CoffeeGrams != req.CoffeeGrams
+	if moved {
+		r.restoreStock(log, repository.StockNoteBrewLogChanged)
+	}
+	// 別のレシピの「Brewed it!」として残らないよう公開をやめる
+	if log.RecipeID != req.RecipeID {
+		log.SharedAt = nil
+		log.Modifications = ""
+	}
+	applyBrewLogRequest(&log, req)
+	if moved {
+		log.StockDeductedGrams = r.deductStock(&log)
+	}
+
+	r.brewLogs[id] = log
+	return &log, nil
+}
+
+func (r *brewLogRepository) Delete(ctx context.Context, id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log, ok := r.brewLogs[id]
+	if !ok || log.UserID != userID {
+		return repository.ErrNotFound
+	}
+	r.restoreStock(log, repository.StockNoteBrewLogDeleted)
+	delete(r.brewLogs, id)
+	delete(r.hidden, hiddenKey{table: tableBrewLogs, id: id})
+	// bean_stock_movements.brew_log_id は ON DELETE SET NULL
+	for i := range r.movements {
+		if r.movements[i].BrewLogID == id {
+			r.movements[i].BrewLogID = ""
+		}
+	}
+	return nil
+}
+
+func applyBrewLogRequest(log *models.BrewLog, req models.CreateBrewLogRequest) {
+	log.RecipeID = req.RecipeID
+	log.RecipeVersion = req.RecipeVersion
+	log.BeanID = req.BeanID
+	log.ActualDuration = req.ActualDuration
+	log.CoffeeGrams = req.CoffeeGrams
+	log.WaterMl = req.WaterMl
+	log.WaterTemperature = req.WaterTemperature
+	log.GrindSetting = req.GrindSetting
+	log.YieldGrams = req.YieldGrams
+	log.Rating = req.Rating
+	log.TasteNotes = append([]models.TasteNote(nil), req.TasteNotes...)
+	log.Memo = req.Memo
+}
+
+// deductStock 実際の豆量を在庫を上限に差し引いて brew として台帳に記録し、差し引いた量を返す
+// 呼び出し側でロックを保持すること
+func (r *brewLogRepository) deductStock(log *models.BrewLog) int {
+	bean, ok := r.beans[log.BeanID]
+	if !ok || bean.UserID != log.UserID {
+		return 0
+	}
+
+	// PostgreSQL の INTEGER への代入と同じく四捨五入
+	deducted := max(min(int(math.Round(log.CoffeeGrams)), bean.StockGrams), 0)
+	if deducted > 0 {
+		r.addMovement(models.StockMovement{
+			BeanID: log.BeanID, UserID: log.UserID, Type: models.StockMovementBrew, Grams: -deducted, BrewLogID: log.ID,
+		})
+	}
+	return deducted
+}
+
+// restoreStock 差し引いた在庫を brew の取り消しとして台帳に戻す（豆が削除済みなら何もしない）
+// 呼び出し側でロックを保持すること
+func (r *brewLogRepository) restoreStock(log models.BrewLog, note string) {
+	bean, ok := r.beans[log.BeanID]
+	if !ok || bean.UserID != log.UserID || log.StockDeductedGrams <= 0 {
+		return
+	}
+	r.addMovement(models.StockMovement{
+		BeanID: log.BeanID, UserID: log.UserID, Type: models.StockMovementBrew, Grams: log.StockDeductedGrams,
+		BrewLogID: log.ID, Note: note,
+	})
+}
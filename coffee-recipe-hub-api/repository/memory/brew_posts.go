@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+// brewPostSorts ソートキーごとの比較関数
+var brewPostSorts = map[string]func(a, b models.BrewPost) int{
+	"sharedAt": func(a, b models.BrewPost) int { return a.SharedAt.Compare(b.SharedAt) },
+	"rating":   func(a, b models.BrewPost) int { return cmp.Compare(a.Rating, b.Rating) },
+}
+
+func (r *brewLogRepository) Share(ctx context.Context, id, userID string, req models.ShareBrewLogRequest) (*models.BrewLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log, ok := r.brewLogs[id]
+	if !ok || log.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	if log.SharedAt == nil {
+		now := time.Now()
+		log.SharedAt = &now
+	}
+	log.Modifications = req.Modifications
+	r.brewLogs[id] = log
+	return &log, nil
+}
+
+func (r *brewLogRepository) Unshare(ctx context.Context, id, userID string) (*models.BrewLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log, ok := r.brewLogs[id]
+	if !ok || log.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	log.SharedAt = nil
+	log.Modifications = ""
+	r.brewLogs[id] = log
+	return &log, nil
+}
+
+func (r *brewLogRepository) ListShared(ctx context.Context, recipeID string, opts repository.ListOptions) (repository.Page[models.BrewPost], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	posts := []models.BrewPost{}
+	for _, log := range r.sharedLogs(recipeID) {
+		posts = append(posts, brewPostOf(log))
+	}
+	sortItems(posts, opts.Desc, brewPostSorts[opts.Sort], func(p models.BrewPost) string { return p.ID })
+	return paginate(posts, opts)
+}
+
+func (r *brewLogRepository) SharedSummary(ctx context.Context, recipeID string) (*models.BrewSummary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := &models.BrewSummary{TasteProfile: []models.TasteAverage{}}
+	ratingSum := 0
+	scoreSums := map[string]int{}
+	counts := map[string]int{}
+	for _, log := range r.sharedLogs(recipeID) {
+		summary.BrewCount++
+		ratingSum += log.Rating
+		for _, note := range log.TasteNotes {
+			scoreSums[note.Aspect] += note.Score
+			counts[note.Aspect]++
+		}
+	}
+	if summary.BrewCount > 0 {
+		summary.AverageRating = round2(float64(ratingSum) / float64(summary.BrewCount))
+	}
+	for aspect, count := range counts {
+		summary.TasteProfile = append(summary.TasteProfile, models.TasteAverage{
+			Aspect: aspect, Average: round2(float64(scoreSums[aspect]) / float64(count)), Count: count,
+		})
+	}
+	slices.SortFunc(summary.TasteProfile, func(a, b models.TasteAverage) int { return cmp.Compare(a.Aspect, b.Aspect) })
+	return summary, nil
+}
+
+// sharedLogs レシピに公開された非表示でない抽出ログ（呼び出し側でロックを保持すること）
+func (r *brewLogRepository) sharedLogs(recipeID string) []models.BrewLog {
+	var logs []models.BrewLog
+	for _, log := range r.brewLogs {
+		if log.RecipeID == recipeID && log.SharedAt != nil && !r.isHidden(tableBrewLogs, log.ID) {
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+// brewPostOf 公開された抽出ログから公開する項目だけを取り出す
+func brewPostOf(log models.BrewLog) models.BrewPost {
+	return models.BrewPost{
+		ID:               log.ID,
+		RecipeID:         log.RecipeID,
+		RecipeVersion:    log.RecipeVersion,
+		UserID:           log.UserID,
+		BrewDate:         log.BrewDate,
+		ActualDuration:   log.ActualDuration,
+		CoffeeGrams:      log.CoffeeGrams,
+		WaterMl:          log.WaterMl,
+		WaterTemperature: log.WaterTemperature,
+		GrindSetting:     log.GrindSetting,
+		YieldGrams:       log.YieldGrams,
+		Rating:           log.Rating,
+		TasteNotes:       append([]models.TasteNote(nil), log.TasteNotes...),
+		Memo:             log.Memo,
+		Modifications:    log.Modifications,
+		SharedAt:         *log.SharedAt,
+	}
+}
+
+// round2 PostgreSQL の ROUND(x, 2) と同じく小数第2位に四捨五入
+func round2(x float64) float64 {
+	return math.Round(x*100) / 100
+}
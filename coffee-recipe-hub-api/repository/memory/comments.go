@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type commentRepository struct {
+	*db
+}
+
+// commentSorts ソートキーごとの比較関数
+var commentSorts = map[string]func(a, b models.Comment) int{
+	"createdAt": func(a, b models.Comment) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *commentRepository) List(ctx context.Context, recipeID string, opts repository.ListOptions) (repository.Page[models.Comment], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replies := map[string][]models.Comment{}
+	for _, c := range r.comments {
+		if c.RecipeID != recipeID || c.ParentID == "" {
+			continue
+		}
+		if c = r.commentView(c); !c.Deleted {
+			replies[c.ParentID] = append(replies[c.ParentID], c)
+		}
+	}
+
+	comments := []models.Comment{}
+	for _, c := range r.comments {
+		if c.RecipeID != recipeID || c.ParentID != "" {
+			continue
+		}
+		// 削除済みでも返信が残っていれば tombstone として残す
+		c = r.commentView(c)
+		if c.Deleted && len(replies[c.ID]) == 0 {
+			continue
+		}
+		c.Replies = replies[c.ID]
+		slices.SortFunc(c.Replies, func(a, b models.Comment) int {
+			return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+		})
+		comments = append(comments, c)
+	}
+	sortItems(comments, opts.Desc, commentSorts[opts.Sort], func(c models.Comment) string { return c.ID })
+	return paginate(comments, opts)
+}
+
+func (r *commentRepository) Get(ctx context.Context, id string) (*models.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.comments[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	c = r.commentView(c)
+	return &c, nil
+}
+
+func (r *commentRepository) Create(ctx context.Context, recipeID, userID string, req models.CreateCommentRequest) (*models.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.recipes[recipeID]; !ok {
+		return nil, repository.ErrNotFound
+	}
+	r.ensureProfile(userID)
+	c := models.Comment{
+		ID: newID(), RecipeID: recipeID, ParentID: req.ParentID, UserID: userID, Body: req.Body, CreatedAt: time.Now(),
+	}
+	r.comments[c.ID] = c
+	c = r.commentView(c)
+	return &c, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, id, userID string, req models.UpdateCommentRequest) (*models.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.comments[id]
+	if !ok || c.UserID != userID || r.commentView(c).Deleted {
+		return nil, repository.ErrNotFound
+	}
+	now := time.Now()
+	c.Body = req.Body
+	c.EditedAt = &now
+	r.comments[id] = c
+	c = r.commentView(c)
+	return &c, nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.comments[id]
+	if !ok || c.Deleted || (c.UserID != userID && r.recipes[c.RecipeID].UserID != userID) {
+		return repository.ErrNotFound
+	}
+	c.Deleted = true
+	r.comments[id] = c
+	return nil
+}
+
+// commentView 返却用のコピーに投稿者のプロフィールの表示名を設定する
+// 管理者が非表示にしたコメントは削除済みとして扱う
+// 呼び出し側でロックを保持すること
+func (d *db) commentView(c models.Comment) models.Comment {
+	c.AuthorName = d.profiles[c.UserID].DisplayName
+	c.Deleted = c.Deleted || d.isHidden(tableComments, c.ID)
+	c.Replies = nil
+	return c
+}
+
+// deleteComment コメントを物理削除する（返信は ON DELETE CASCADE。通報は外部キーがないため残る）
+// 呼び出し側でロックを保持すること
+func (d *db) deleteComment(id string) {
+	delete(d.comments, id)
+	delete(d.hidden, hiddenKey{table: tableComments, id: id})
+	for replyID, reply := range d.comments {
+		if reply.ParentID == id {
+			d.deleteComment(replyID)
+		}
+	}
+}
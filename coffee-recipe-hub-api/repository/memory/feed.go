@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type feedRepository struct {
+	*db
+}
+
+// feedSorts ソートキーごとの比較関数
+var feedSorts = map[string]func(a, b models.FeedItem) int{
+	"createdAt": func(a, b models.FeedItem) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *feedRepository) Feed(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.FeedItem], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	followees := r.following(userID)
+	items := []models.FeedItem{}
+	for _, recipe := range r.recipes {
+		if !r.publicRecipe(recipe) || !followees[recipe.UserID] {
+			continue
+		}
+		recipe := r.recipeView(recipe)
+		items = append(items, models.FeedItem{
+			Type: models.FeedItemRecipe, ID: recipe.ID, UserID: recipe.UserID, CreatedAt: recipe.CreatedAt, Recipe: &recipe,
+		})
+	}
+	for _, log := range r.brewLogs {
+		if log.SharedAt == nil || r.isHidden(tableBrewLogs, log.ID) || !followees[log.UserID] {
+			continue
+		}
+		if recipe, ok := r.recipes[log.RecipeID]; !ok || !r.publicRecipe(recipe) {
+			continue
+		}
+		post := brewPostOf(log)
+		items = append(items, models.FeedItem{
+			Type: models.FeedItemBrew, ID: log.ID, UserID: log.UserID, CreatedAt: post.SharedAt, Brew: &post,
+		})
+	}
+	sortItems(items, opts.Desc, feedSorts[opts.Sort], func(item models.FeedItem) string { return item.ID })
+	return paginate(items, opts)
+}
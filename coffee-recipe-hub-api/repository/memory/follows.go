@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type followRepository struct {
+	*db
+}
+
+// followSorts ソートキーごとの比較関数
+var followSorts = map[string]func(a, b models.FollowUser) int{
+	"createdAt": func(a, b models.FollowUser) int { return a.FollowedAt.Compare(b.FollowedAt) },
+}
+
+// Follow インメモリではユーザーの存在を確認できないため、どのIDでもフォローできる
+func (r *followRepository) Follow(ctx context.Context, followerID, followeeID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := followKey{followerID: followerID, followeeID: followeeID}
+	if _, ok := r.follows[key]; !ok {
+		r.follows[key] = time.Now()
+	}
+	return nil
+}
+
+func (r *followRepository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.follows, followKey{followerID: followerID, followeeID: followeeID})
+	return nil
+}
+
+func (r *followRepository) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.follows[followKey{followerID: followerID, followeeID: followeeID}]
+	return ok, nil
+}
+
+func (r *followRepository) ListFollowers(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.FollowUser], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users := []models.FollowUser{}
+	for key, at := range r.follows {
+		if key.followeeID == userID {
+			users = append(users, models.FollowUser{UserID: key.followerID, FollowedAt: at})
+		}
+	}
+	sortItems(users, opts.Desc, followSorts[opts.Sort], func(f models.FollowUser) string { return f.UserID })
+	return paginate(users, opts)
+}
+
+func (r *followRepository) ListFollowing(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.FollowUser], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users := []models.FollowUser{}
+	for key, at := range r.follows {
+		if key.followerID == userID {
+			users = append(users, models.FollowUser{UserID: key.followeeID, FollowedAt: at})
+		}
+	}
+	sortItems(users, opts.Desc, followSorts[opts.Sort], func(f models.FollowUser) string { return f.UserID })
+	return paginate(users, opts)
+}
+
+// following followerID がフォローしているユーザー（呼び出し側でロックを保持すること）
+func (d *db) following(followerID string) map[string]bool {
+	ids := map[string]bool{}
+	for key := range d.follows {
+		if key.followerID == followerID {
+			ids[key.followeeID] = true
+		}
+	}
+	return ids
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type likeRepository struct {
+	*db
+}
+
+// likedRecipeSorts ソートキーごとの比較関数
+var likedRecipeSorts = map[string]func(a, b models.Recipe) int{
+	"likedAt": func(a, b models.Recipe) int { return a.LikedAt.Compare(*b.LikedAt) },
+}
+
+func (r *likeRepository) Like(ctx context.Context, userID, recipeID string) (*models.LikeStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if recipe, ok := r.recipes[recipeID]; !ok || !r.publicRecipe(recipe) {
+		return nil, repository.ErrNotFound
+	}
+	key := likeKey{userID: userID, recipeID: recipeID}
+	if _, ok := r.likes[key]; !ok {
+		r.likes[key] = time.Now()
+	}
+	return &models.LikeStatus{Liked: true, LikeCount: r.refreshCount(recipeID)}, nil
+}
+
+func (r *likeRepository) Unlike(ctx context.Context, userID, recipeID string) (*models.LikeStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if recipe, ok := r.recipes[recipeID]; !ok || !r.publicRecipe(recipe) {
+		return nil, repository.ErrNotFound
+	}
+	delete(r.likes, likeKey{userID: userID, recipeID: recipeID})
+	return &models.LikeStatus{Liked: false, LikeCount: r.refreshCount(recipeID)}, nil
+}
+
+// refreshCount 更新後の件数を返す
+// 呼び出し側でロックを保持すること
+func (r *likeRepository) refreshCount(recipeID string) int {
+	recipe, ok := r.recipes[recipeID]
+	if !ok {
+		return 0
+	}
+	count := 0
+	for key := range r.likes {
+		if key.recipeID == recipeID {
+			count++
+		}
+	}
+	recipe.LikeCount = count
+	r.recipes[recipeID] = recipe
+	return count
+}
+
+func (r *likeRepository) IsLiked(ctx context.Context, userID, recipeID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.likes[likeKey{userID: userID, recipeID: recipeID}]
+	return ok, nil
+}
+
+func (r *likeRepository) ListLiked(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.Recipe], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipes := []models.Recipe{}
+	for key, likedAt := range r.likes {
+		if key.userID != userID {
+			continue
+		}
+		recipe, ok := r.recipes[key.recipeID]
+		if !ok || !r.visibleRecipe(recipe, userID) {
+			continue
+		}
+		recipe = r.recipeView(recipe)
+		recipe.LikedByMe = true
+		recipe.LikedAt = &likedAt
+		recipes = append(recipes, recipe)
+	}
+	sortItems(recipes, opts.Desc, likedRecipeSorts[opts.Sort], func(r models.Recipe) string { return r.ID })
+	return paginate(recipes, opts)
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+// offsetCursor インメモリ実装では並べ替え後の位置をカーソルにする
+type offsetCursor struct {
+	Sort   string `json:"s"`
+	Desc   bool   `json:"d"`
+	Offset int    `json:"o"`
+}
+
+// sortItems key で並べ、同値は id で並べる（PostgreSQL実装のキーセット順と同じ）
+func sortItems[T any](items []T, desc bool, key func(a, b T) int, id func(T) string) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		c := key(a, b)
+		if c == 0 {
+			c = cmp.Compare(id(a), id(b))
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+}
+
+// paginate 並べ替え済みの一覧から1ページ分を切り出す
+func paginate[T any](items []T, opts repository.ListOptions) (repository.Page[T], error) {
+	start := 0
+	if opts.Cursor != "" {
+		var cur offsetCursor
+		if err := repository.DecodeCursor(opts.Cursor, &cur); err != nil {
+			return repository.Page[T]{}, err
+		}
+		if cur.Sort != opts.Sort || cur.Desc != opts.Desc || cur.Offset < 0 {
+			return repository.Page[T]{}, repository.ErrInvalidCursor
+		}
+		start = min(cur.Offset, len(items))
+	}
+
+	end := min(start+opts.Limit, len(items))
+	page := repository.Page[T]{Items: append([]T{}, items[start:end]...)}
+	if end < len(items) {
+		page.NextCursor = repository.EncodeCursor(offsetCursor{Sort: opts.Sort, Desc: opts.Desc, Offset: end})
+	}
+	return page, nil
+}
+
+// inRange from / to（いずれも省略可）の範囲内か
+func inRange(t time.Time, from, to *time.Time) bool {
+	if from != nil && t.Before(*from) {
+		return false
+	}
+	if to != nil && t.After(*to) {
+		return false
+	}
+	return true
+}
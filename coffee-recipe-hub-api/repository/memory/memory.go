@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/ranking"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+// db 全リポジトリで共有するインメモリのデータ
+// 抽出ログ作成時の在庫更新のように複数リポジトリにまたがる操作を1つのロックで扱う
+type db struct {
+	mu        sync.Mutex
+	beans     map[string]models.Bean
+	recipes   map[string]models.Recipe
+	brewLogs  map[string]models.BrewLog
+	likes     map[likeKey]time.Time
+	movements []models.StockMovement             // 在庫台帳（記録順）
+	revisions map[string][]models.RecipeRevision // レシピIDごとの版（版番号順）
+
+	notifications map[string]models.Notification
+	follows       map[followKey]time.Time
+	profiles      map[string]models.User
+	scores        map[string]ranking.Score // 集計済みの公開レシピのスコア（なければ 0）
+	comments      map[string]models.Comment
+	reports       map[string]models.Report
+	hidden        map[hiddenKey]string // 非表示にしたコンテンツ（値は非表示の理由）
+}
+
+type likeKey struct {
+	userID   string
+	recipeID string
+}
+
+type followKey struct {
+	followerID string
+	followeeID string
+}
+
+// New インメモリのリポジトリ一式を作成（テスト・ローカル開発用）
+func New() *repository.Store {
+	d := &db{
+		beans:    map[string]models.Bean{},
+		recipes:  map[string]models.Recipe{},
+		brewLogs: map[string]models.BrewLog{},
+		likes:    map[likeKey]time.Time{},
+
+		revisions: map[string][]models.RecipeRevision{},
+
+		notifications: map[string]models.Notification{},
+		follows:       map[followKey]time.Time{},
+		profiles:      map[string]models.User{},
+		scores:        map[string]ranking.Score{},
+		comments:      map[string]models.Comment{},
+		reports:       map[string]models.Report{},
+		hidden:        map[hiddenKey]string{},
+	}
+	return &repository.Store{
+		Beans:         &beanRepository{d},
+		Recipes:       &recipeRepository{d},
+		BrewLogs:      &brewLogRepository{d},
+		Likes:         &likeRepository{d},
+		Search:        &searchRepository{d},
+		Notifications: &notificationRepository{d},
+		Profiles:      &profileRepository{d},
+		Follows:       &followRepository{d},
+		Feed:          &feedRepository{d},
+		Comments:      &commentRepository{d},
+		Moderation:    &moderationRepository{d},
+	}
+}
+
+// newID UUID v4 を生成
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// cloneStrings 呼び出し側とスライスを共有しないようにコピー
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+// cloneInt 呼び出し側とポインタを共有しないようにコピー
+func cloneInt(n *int) *int {
+	if n == nil {
+		return nil
+	}
+	v := *n
+	return &v
+}
@@ -0,0 +1,270 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type moderationRepository struct {
+	*db
+}
+
+// 非表示にできるコンテンツ（PostgreSQL のテーブル名）
+const (
+	tableRecipes  = "recipes"
+	tableComments = "recipe_comments"
+	tableBrewLogs = "brew_logs"
+)
+
+// 非表示の理由（PostgreSQL の hidden_reason と同じ）
+const (
+	hiddenByModeration = "moderation"
+	hiddenByBan        = "ban"
+)
+
+// hiddenKey 非表示にしたコンテンツ
+type hiddenKey struct {
+	table string
+	id    string
+}
+
+// hideableTables 管理者が個別に非表示にできる対象
+var hideableTables = map[models.ReportTargetType]string{
+	models.ReportTargetRecipe:  tableRecipes,
+	models.ReportTargetComment: tableComments,
+}
+
+// reportSorts ソートキーごとの比較関数
+var reportSorts = map[string]func(a, b models.Report) int{
+	"createdAt": func(a, b models.Report) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *moderationRepository) CreateReport(ctx context.Context, reporterID string, req models.CreateReportRequest) (*models.Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.reportable(req.TargetType, req.TargetID) {
+		return nil, repository.ErrNotFound
+	}
+	// 通報済みなら何も変えずに既存の通報を返す
+	for _, report := range r.reports {
+		if report.TargetType == req.TargetType && report.TargetID == req.TargetID && report.ReporterID == reporterID {
+			return &report, nil
+		}
+	}
+	report := models.Report{
+		ID: newID(), TargetType: req.TargetType, TargetID: req.TargetID, ReporterID: reporterID,
+		Reason: req.Reason, Status: models.ReportPending, CreatedAt: time.Now(),
+	}
+	r.reports[report.ID] = report
+	return &report, nil
+}
+
+// reportable 通報できる対象（公開されていて非表示・削除・利用停止になっていない）か
+// 呼び出し側でロックを保持すること
+func (r *moderationRepository) reportable(targetType models.ReportTargetType, id string) bool {
+	switch targetType {
+	case models.ReportTargetRecipe:
+		recipe, ok := r.recipes[id]
+		return ok && r.publicRecipe(recipe)
+	case models.ReportTargetComment:
+		c, ok := r.comments[id]
+		return ok && !r.commentView(c).Deleted && r.publicRecipe(r.recipes[c.RecipeID])
+	case models.ReportTargetUser:
+		u, ok := r.profiles[id]
+		return ok && !u.Banned
+	}
+	return false
+}
+
+func (r *moderationRepository) ListReports(ctx context.Context, f repository.ReportFilter) (repository.Page[models.Report], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reports := []models.Report{}
+	for _, report := range r.reports {
+		if f.Status != "" && report.Status != f.Status {
+			continue
+		}
+		if f.TargetType != "" && report.TargetType != f.TargetType {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	sortItems(reports, f.Desc, reportSorts[f.Sort], func(r models.Report) string { return r.ID })
+	return paginate(reports, f.ListOptions)
+}
+
+func (r *moderationRepository) UpdateReport(ctx context.Context, id, adminID string, status models.ReportStatus) (*models.Report, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report, ok := r.reports[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	r.resolveReport(&report, adminID, status)
+	return &report, nil
+}
+
+// resolveReport 通報の対応状況を更新して保存する
+// 呼び出し側でロックを保持すること
+func (r *moderationRepository) resolveReport(report *models.Report, adminID string, status models.ReportStatus) {
+	now := time.Now()
+	report.Status = status
+	report.ResolvedAt = &now
+	report.ResolvedBy = adminID
+	r.reports[report.ID] = *report
+}
+
+// resolvePending match に一致するレビュー待ちの通報を対応済みにする
+// 呼び出し側でロックを保持すること
+func (r *moderationRepository) resolvePending(adminID string, match func(models.Report) bool) {
+	for _, report := range r.reports {
+		if report.Status == models.ReportPending && match(report) {
+			r.resolveReport(&report, adminID, models.ReportResolved)
+		}
+	}
+}
+
+func (r *moderationRepository) SetHidden(ctx context.Context, targetType models.ReportTargetType, id, adminID string, hidden bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	table, ok := hideableTables[targetType]
+	if !ok || !r.exists(table, id) {
+		return repository.ErrNotFound
+	}
+	key := hiddenKey{table: table, id: id}
+	if !hidden {
+		delete(r.hidden, key)
+		return nil
+	}
+	// 利用停止で非表示になっていたものも個別の非表示に切り替え、利用停止の解除では戻さない
+	r.hidden[key] = hiddenByModeration
+	r.resolvePending(adminID, func(report models.Report) bool {
+		return report.TargetType == targetType && report.TargetID == id
+	})
+	return nil
+}
+
+// exists 呼び出し側でロックを保持すること
+func (d *db) exists(table, id string) bool {
+	var ok bool
+	switch table {
+	case tableRecipes:
+		_, ok = d.recipes[id]
+	case tableComments:
+		_, ok = d.comments[id]
+	case tableBrewLogs:
+		_, ok = d.brewLogs[id]
+	}
+	return ok
+}
+
+func (r *moderationRepository) Ban(ctx context.Context, userID, adminID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u := r.ensureProfile(userID)
+	u.Banned = true
+	r.profiles[userID] = u
+
+	hide := func(table, id string) {
+		key := hiddenKey{table: table, id: id}
+		if _, ok := r.hidden[key]; !ok {
+			r.hidden[key] = hiddenByBan
+		}
+	}
+	for id, recipe := range r.recipes {
+		if recipe.UserID == userID {
+			hide(tableRecipes, id)
+		}
+	}
+	for id, c := range r.comments {
+		if c.UserID == userID {
+			hide(tableComments, id)
+		}
+	}
+	for id, log := range r.brewLogs {
+		if log.UserID == userID {
+			hide(tableBrewLogs, id)
+		}
+	}
+	r.resolvePending(adminID, func(report models.Report) bool {
+		switch report.TargetType {
+		case models.ReportTargetUser:
+			return report.TargetID == userID
+		case models.ReportTargetRecipe:
+			recipe, ok := r.recipes[report.TargetID]
+			return ok && recipe.UserID == userID
+		case models.ReportTargetComment:
+			c, ok := r.comments[report.TargetID]
+			return ok && c.UserID == userID
+		}
+		return false
+	})
+	return nil
+}
+
+func (r *moderationRepository) Unban(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.profiles[userID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	u.Banned = false
+	r.profiles[userID] = u
+
+	for key, reason := range r.hidden {
+		if reason == hiddenByBan && r.ownerOf(key) == userID {
+			delete(r.hidden, key)
+		}
+	}
+	return nil
+}
+
+// ownerOf 非表示にしたコンテンツの作者
+// 呼び出し側でロックを保持すること
+func (d *db) ownerOf(key hiddenKey) string {
+	switch key.table {
+	case tableRecipes:
+		return d.recipes[key.id].UserID
+	case tableComments:
+		return d.comments[key.id].UserID
+	case tableBrewLogs:
+		return d.brewLogs[key.id].UserID
+	}
+	return ""
+}
+
+func (r *moderationRepository) IsBanned(ctx context.Context, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.profiles[userID].Banned, nil
+}
+
+// isHidden 管理者が非表示にしたか（利用停止に伴う非表示を含む）
+// 呼び出し側でロックを保持すること
+func (d *db) isHidden(table, id string) bool {
+	_, ok := d.hidden[hiddenKey{table: table, id: id}]
+	return ok
+}
+
+// publicRecipe 作者以外にも見えるレシピ（公開されていて非表示になっていない）か
+// 呼び出し側でロックを保持すること
+func (d *db) publicRecipe(recipe models.Recipe) bool {
+	return recipe.IsPublic && !d.isHidden(tableRecipes, recipe.ID)
+}
+
+// visibleRecipe viewerID（未ログインなら空）のユーザーに見えるレシピか
+// 呼び出し側でロックを保持すること
+func (d *db) visibleRecipe(recipe models.Recipe, viewerID string) bool {
+	return (viewerID != "" && recipe.UserID == viewerID) || d.publicRecipe(recipe)
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type notificationRepository struct {
+	*db
+}
+
+func (r *notificationRepository) ListByUser(ctx context.Context, userID string, f repository.NotificationFilter) (repository.Page[models.Notification], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notifications := []models.Notification{}
+	for _, n := range r.notifications {
+		if n.UserID != userID {
+			continue
+		}
+		if f.UnreadOnly && n.ReadAt != nil {
+			continue
+		}
+		notifications = append(notifications, n)
+	}
+	sortItems(notifications, f.Desc, func(a, b models.Notification) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		func(n models.Notification) string { return n.ID })
+	return paginate(notifications, f.ListOptions)
+}
+
+func (r *notificationRepository) Create(ctx context.Context, n models.Notification) (*models.Notification, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.notifications {
+		if existing.UserID == n.UserID && existing.DedupKey == n.DedupKey {
+			return nil, false, nil
+		}
+	}
+	n.ID = newID()
+	n.ReadAt = nil
+	n.CreatedAt = time.Now()
+	r.notifications[n.ID] = n
+	return &n, true, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, ok := r.notifications[id]
+	if !ok || n.UserID != userID {
+		return repository.ErrNotFound
+	}
+	if n.ReadAt == nil {
+		now := time.Now()
+		n.ReadAt = &now
+		r.notifications[id] = n
+	}
+	return nil
+}
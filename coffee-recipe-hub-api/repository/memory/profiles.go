@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type profileRepository struct {
+	*db
+}
+
+func (r *profileRepository) Get(ctx context.Context, userID string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.profiles[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &u, nil
+}
+
+// Ensure インメモリではユーザーの存在を確認できないため、どのIDでも作成する
+func (r *profileRepository) Ensure(ctx context.Context, userID string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u := r.ensureProfile(userID)
+	return &u, nil
+}
+
+func (r *profileRepository) Update(ctx context.Context, userID string, req models.UpdateProfileRequest) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u := r.ensureProfile(userID)
+	u.DisplayName = req.DisplayName
+	u.AvatarURL = req.AvatarURL
+	r.profiles[userID] = u
+	return &u, nil
+}
+
+func (r *profileRepository) Stats(ctx context.Context, userID string) (*models.UserStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var s models.UserStats
+	for _, recipe := range r.recipes {
+		if recipe.UserID != userID || !r.publicRecipe(recipe) {
+			continue
+		}
+		s.PublicRecipeCount++
+		s.LikesReceived += recipe.LikeCount
+		s.ForksReceived += recipe.ForkCount
+	}
+	for _, log := range r.brewLogs {
+		if log.UserID != userID || log.SharedAt == nil || r.isHidden(tableBrewLogs, log.ID) {
+			continue
+		}
+		if recipe, ok := r.recipes[log.RecipeID]; ok && r.publicRecipe(recipe) {
+			s.SharedBrewCount++
+		}
+	}
+	for key := range r.follows {
+		if key.followeeID == userID {
+			s.FollowerCount++
+		}
+		if key.followerID == userID {
+			s.FollowingCount++
+		}
+	}
+	return &s, nil
+}
+
+// ensureProfile プロフィールがなければ空の表示名で作成する
+// PostgreSQL ではユーザー登録時のトリガーで作られるため、インメモリではレシピ作成時などに作る
+// 呼び出し側でロックを保持すること
+func (d *db) ensureProfile(userID string) models.User {
+	u, ok := d.profiles[userID]
+	if !ok {
+		u = models.User{ID: userID, CreatedAt: time.Now()}
+		d.profiles[userID] = u
+	}
+	return u
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/ranking"
+)
+
+func (r *recipeRepository) RefreshScores(ctx context.Context, cfg ranking.Config, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	since := cfg.Since(now)
+	var events []ranking.Event
+	add := func(recipeID string, weight float64, at time.Time) {
+		if !at.Before(since) {
+			events = append(events, ranking.Event{RecipeID: recipeID, Weight: weight, At: at})
+		}
+	}
+	for key, likedAt := range r.likes {
+		add(key.recipeID, ranking.LikeWeight, likedAt)
+	}
+	for _, recipe := range r.recipes {
+		if recipe.ForkedFromRecipeID != "" {
+			add(recipe.ForkedFromRecipeID, ranking.ForkWeight, recipe.CreatedAt)
+		}
+	}
+	for _, log := range r.brewLogs {
+		if log.SharedAt != nil && log.RecipeID != "" && !r.isHidden(tableBrewLogs, log.ID) {
+			add(log.RecipeID, ranking.BrewWeight, *log.SharedAt)
+		}
+	}
+
+	scores := cfg.Scores(events, now)
+	for id := range scores {
+		if recipe, ok := r.recipes[id]; !ok || !r.publicRecipe(recipe) {
+			delete(scores, id)
+		}
+	}
+	r.scores = scores
+	return nil
+}
@@ -0,0 +1,382 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type recipeRepository struct {
+	*db
+}
+
+// recipeSorts ソートキーごとの比較関数
+var recipeSorts = map[string]func(a, b models.Recipe) int{
+	"createdAt":   func(a, b models.Recipe) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"updatedAt":   func(a, b models.Recipe) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+	"title":       func(a, b models.Recipe) int { return strings.Compare(a.Title, b.Title) },
+	"likeCount":   func(a, b models.Recipe) int { return cmp.Compare(a.LikeCount, b.LikeCount) },
+	"coffeeGrams": func(a, b models.Recipe) int { return cmp.Compare(a.CoffeeGrams, b.CoffeeGrams) },
+	"new":         func(a, b models.Recipe) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+// sortFunc ソートキーの比較関数（trending / top は集計済みのスコアで比べる）
+// 呼び出し側でロックを保持すること
+func (r *recipeRepository) sortFunc(sort string) func(a, b models.Recipe) int {
+	switch sort {
+	case "trending":
+		return func(a, b models.Recipe) int { return cmp.Compare(r.scores[a.ID].Trending, r.scores[b.ID].Trending) }
+	case "top":
+		return func(a, b models.Recipe) int { return cmp.Compare(r.scores[a.ID].Top, r.scores[b.ID].Top) }
+	}
+	return recipeSorts[sort]
+}
+
+// list visible を満たし絞り込み条件に一致するレシピを1ページ分返す
+func (r *recipeRepository) list(f repository.RecipeFilter, visible func(models.Recipe) bool) (repository.Page[models.Recipe], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipes := []models.Recipe{}
+	for _, recipe := range r.recipes {
+		if !visible(recipe) {
+			continue
+		}
+		if f.UserID != "" && recipe.UserID != f.UserID {
+			continue
+		}
+		if f.Equipment != "" && recipe.Equipment != f.Equipment {
+			continue
+		}
+		if f.GrindSize != "" && recipe.GrindSize != f.GrindSize {
+			continue
+		}
+		if f.Tag != "" && !slices.Contains(recipe.Tags, f.Tag) {
+			continue
+		}
+		if !inRange(recipe.CreatedAt, f.From, f.To) {
+			continue
+		}
+		recipe = r.recipeView(recipe)
+		if f.ViewerID != "" {
+			_, recipe.LikedByMe = r.likes[likeKey{userID: f.ViewerID, recipeID: recipe.ID}]
+		}
+		recipes = append(recipes, recipe)
+	}
+	sortItems(recipes, f.Desc, r.sortFunc(f.Sort), func(r models.Recipe) string { return r.ID })
+	return paginate(recipes, f.ListOptions)
+}
+
+func (r *recipeRepository) ListVisible(ctx context.Context, userID string, f repository.RecipeFilter) (repository.Page[models.Recipe], error) {
+	return r.list(f, func(recipe models.Recipe) bool {
+		return r.visibleRecipe(recipe, userID)
+	})
+}
+
+func (r *recipeRepository) ListPublic(ctx context.Context, f repository.RecipeFilter) (repository.Page[models.Recipe], error) {
+	return r.list(f, r.publicRecipe)
+}
+
+func (r *recipeRepository) Get(ctx context.Context, id string) (*models.Recipe, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipe, ok := r.recipes[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	recipe = r.recipeView(recipe)
+	return &recipe, nil
+}
+
+func (r *recipeRepository) Create(ctx context.Context, userID string, req models.CreateRecipeRequest) (*models.Recipe, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureProfile(userID)
+	now := time.Now()
+	recipe := models.Recipe{ID: newID(), UserID: userID, Version: 1, CreatedAt: now}
+	applyRecipeRequest(&recipe, req, now)
+	r.recipes[recipe.ID] = recipe
+	r.snapshotRevision(recipe, nil)
+	recipe = r.recipeView(recipe)
+	return &recipe, nil
+}
+
+func (r *recipeRepository) Update(ctx context.Context, id, userID string, req models.CreateRecipeRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipe, ok := r.recipes[id]
+	if !ok || recipe.UserID != userID {
+		return repository.ErrNotFound
+	}
+	// 公開設定だけの変更では版を増やさない
+	before := brewing.Revision(&recipe)
+	applyRecipeRequest(&recipe, req, time.Now())
+	after := brewing.Revision(&recipe)
+	changed := brewing.Changed(&before, &after)
+	if changed {
+		recipe.Version++
+	}
+	r.recipes[id] = recipe
+	if changed {
+		r.snapshotRevision(recipe, nil)
+	}
+	return nil
+}
+
+func (r *recipeRepository) Delete(ctx context.Context, id, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipe, ok := r.recipes[id]
+	if !ok || recipe.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(r.recipes, id)
+	delete(r.revisions, id)
+	delete(r.hidden, hiddenKey{table: tableRecipes, id: id})
+	// フォークを削除したら元レシピのフォーク数を更新する
+	if recipe.ForkedFromRecipeID != "" {
+		r.refreshForkCount(recipe.ForkedFromRecipeID)
+	}
+	// recipe_likes・recipe_revisions・recipe_comments は ON DELETE CASCADE、
+	// brew_logs.recipe_id・recipes.forked_from_recipe_id は ON DELETE SET NULL
+	for childID, child := range r.recipes {
+		if child.ForkedFromRecipeID == id {
+			child.ForkedFromRecipeID = ""
+			r.recipes[childID] = child
+		}
+	}
+	for key := range r.likes {
+		if key.recipeID == id {
+			delete(r.likes, key)
+		}
+	}
+	for logID, log := range r.brewLogs {
+		if log.RecipeID == id {
+			log.RecipeID = ""
+			r.brewLogs[logID] = log
+		}
+	}
+	for commentID, comment := range r.comments {
+		if comment.RecipeID == id {
+			r.deleteComment(commentID)
+		}
+	}
+	return nil
+}
+
+func applyRecipeRequest(recipe *models.Recipe, req models.CreateRecipeRequest, now time.Time) {
+	recipe.Title = req.Title
+	recipe.Equipment = req.Equipment
+	recipe.CoffeeGrams = req.CoffeeGrams
+	recipe.TotalWaterMl = req.TotalWaterMl
+	recipe.WaterTemperature = req.WaterTemperature
+	recipe.GrindSize = req.GrindSize
+	recipe.StepWaterMode = req.StepWaterMode
+	recipe.Steps = append([]models.RecipeStep(nil), req.Steps...)
+	recipe.Tags = cloneStrings(req.Tags)
+	if recipe.Tags == nil {
+		recipe.Tags = []string{}
+	}
+	recipe.IsPublic = req.IsPublic
+	recipe.UpdatedAt = now
+}
+
+// recipeView 返却用のコピーに作者のプロフィールの表示名を設定する
+// 呼び出し側でロックを保持すること
+func (d *db) recipeView(recipe models.Recipe) models.Recipe {
+	recipe = cloneRecipe(recipe)
+	recipe.AuthorName = d.profiles[recipe.UserID].DisplayName
+	recipe.Hidden = d.isHidden(tableRecipes, recipe.ID)
+	if name := d.profiles[recipe.OriginalAuthorID].DisplayName; recipe.OriginalAuthorID != "" && name != "" {
+		recipe.OriginalAuthorName = name
+	}
+	return recipe
+}
+
+// cloneRecipe 呼び出し側が書き換えても保存済みのデータに影響しないようにステップとタグをコピー
+func cloneRecipe(recipe models.Recipe) models.Recipe {
+	recipe.Steps = append([]models.RecipeStep(nil), recipe.Steps...)
+	recipe.Tags = cloneStrings(recipe.Tags)
+	return recipe
+}
+
+// recipeVersionSorts ソートキーごとの比較関数
+var recipeVersionSorts = map[string]func(a, b models.RecipeRevision) int{
+	"version": func(a, b models.RecipeRevision) int { return cmp.Compare(a.Version, b.Version) },
+}
+
+func (r *recipeRepository) ListVersions(ctx context.Context, recipeID string, opts repository.ListOptions) (repository.Page[models.RecipeRevision], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revs := []models.RecipeRevision{}
+	for _, rev := range r.revisions[recipeID] {
+		revs = append(revs, cloneRevision(rev))
+	}
+	sortItems(revs, opts.Desc, recipeVersionSorts[opts.Sort], func(rev models.RecipeRevision) string { return rev.ID })
+	return paginate(revs, opts)
+}
+
+func (r *recipeRepository) GetVersion(ctx context.Context, recipeID string, version int) (*models.RecipeRevision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rev, ok := r.findRevision(recipeID, version)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	rev = cloneRevision(rev)
+	return &rev, nil
+}
+
+func (r *recipeRepository) RestoreVersion(ctx context.Context, id, userID string, version int) (*models.Recipe, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipe, ok := r.recipes[id]
+	if !ok || recipe.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	rev, ok := r.findRevision(id, version)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+
+	recipe.Title = rev.Title
+	recipe.Equipment = rev.Equipment
+	recipe.CoffeeGrams = rev.CoffeeGrams
+	recipe.TotalWaterMl = rev.TotalWaterMl
+	recipe.WaterTemperature = rev.WaterTemperature
+	recipe.GrindSize = rev.GrindSize
+	recipe.StepWaterMode = rev.StepWaterMode
+	recipe.Steps = append([]models.RecipeStep(nil), rev.Steps...)
+	recipe.Tags = cloneStrings(rev.Tags)
+	recipe.Version++
+	recipe.UpdatedAt = time.Now()
+	r.recipes[id] = recipe
+	r.snapshotRevision(recipe, &version)
+
+	recipe = r.recipeView(recipe)
+	return &recipe, nil
+}
+
+func (r *recipeRepository) Fork(ctx context.Context, id, userID string, req models.ForkRecipeRequest) (*models.Recipe, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	original, ok := r.recipes[id]
+	if !ok || !r.visibleRecipe(original, userID) {
+		return nil, repository.ErrNotFound
+	}
+
+	r.ensureProfile(userID)
+	now := time.Now()
+	fork := cloneRecipe(original)
+	fork.ID = newID()
+	fork.UserID = userID
+	if req.Title != "" {
+		fork.Title = req.Title
+	}
+	fork.IsPublic = false
+	fork.LikeCount = 0
+	fork.ForkCount = 0
+	fork.Version = 1
+	fork.ForkedFromRecipeID = original.ID
+	fork.ForkedFromVersion = original.Version
+	fork.OriginalAuthorID = original.UserID
+	fork.OriginalAuthorName = r.profiles[original.UserID].DisplayName
+	fork.CreatedAt = now
+	fork.UpdatedAt = now
+	r.recipes[fork.ID] = fork
+	r.snapshotRevision(fork, nil)
+	r.refreshForkCount(original.ID)
+
+	fork = r.recipeView(fork)
+	return &fork, nil
+}
+
+// maxForkDepth フォークの系統を辿る深さの上限（PostgreSQL実装と同じ）
+const maxForkDepth = 20
+
+func (r *recipeRepository) ListForks(ctx context.Context, id, viewerID string) ([]models.Recipe, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	forks := []models.Recipe{}
+	parents := []string{id}
+	for depth := 1; depth <= maxForkDepth && len(parents) > 0; depth++ {
+		var next []string
+		for _, recipe := range r.recipes {
+			if !slices.Contains(parents, recipe.ForkedFromRecipeID) {
+				continue
+			}
+			if !r.visibleRecipe(recipe, viewerID) {
+				continue
+			}
+			forks = append(forks, r.recipeView(recipe))
+			next = append(next, recipe.ID)
+		}
+		parents = next
+	}
+	slices.SortFunc(forks, func(a, b models.Recipe) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return forks, nil
+}
+
+// refreshForkCount 呼び出し側でロックを保持すること
+func (r *recipeRepository) refreshForkCount(recipeID string) {
+	recipe, ok := r.recipes[recipeID]
+	if !ok {
+		return
+	}
+	count := 0
+	for _, fork := range r.recipes {
+		if fork.ForkedFromRecipeID == recipeID {
+			count++
+		}
+	}
+	recipe.ForkCount = count
+	r.recipes[recipeID] = recipe
+}
+
+// findRevision 呼び出し側でロックを保持すること
+func (r *recipeRepository) findRevision(recipeID string, version int) (models.RecipeRevision, bool) {
+	for _, rev := range r.revisions[recipeID] {
+		if rev.Version == version {
+			return rev, true
+		}
+	}
+	return models.RecipeRevision{}, false
+}
+
+// snapshotRevision レシピの現在の内容を recipe.Version の版として記録する
+// 呼び出し側でロックを保持すること
+func (r *recipeRepository) snapshotRevision(recipe models.Recipe, restoredFrom *int) {
+	rev := brewing.Revision(&recipe)
+	rev.ID = newID()
+	rev.RestoredFrom = cloneInt(restoredFrom)
+	rev.CreatedAt = recipe.UpdatedAt
+	r.revisions[recipe.ID] = append(r.revisions[recipe.ID], rev)
+}
+
+// cloneRevision 呼び出し側が書き換えても保存済みの版に影響しないようにコピー
+func cloneRevision(rev models.RecipeRevision) models.RecipeRevision {
+	rev.Steps = append([]models.RecipeStep(nil), rev.Steps...)
+	rev.Tags = cloneStrings(rev.Tags)
+	rev.RestoredFrom = cloneInt(rev.RestoredFrom)
+	return rev
+}
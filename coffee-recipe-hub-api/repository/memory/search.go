@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type searchRepository struct {
+	*db
+}
+
+// searchField 検索対象のテキストと重み（PostgreSQL実装の setweight A/B/C に相当）
+type searchField struct {
+	text   string
+	weight float64
+}
+
+func (r *searchRepository) Search(ctx context.Context, userID string, q repository.SearchQuery) (repository.Page[models.SearchResult], error) {
+	offset, err := q.Offset()
+	if err != nil {
+		return repository.Page[models.SearchResult]{}, err
+	}
+
+	terms := strings.Fields(strings.ToLower(q.Text))
+	if len(terms) == 0 {
+		return q.PageResults(nil, 0), nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var results []models.SearchResult
+	if q.Includes(models.SearchResultRecipe) {
+		for _, recipe := range r.recipes {
+			if !r.visibleRecipe(recipe, userID) {
+				continue
+			}
+			fields := []searchField{{recipe.Title, 1}, {strings.Join(recipe.Tags, " "), 0.4}}
+			for _, step := range recipe.Steps {
+				fields = append(fields, searchField{step.Label + " " + step.Notes, 0.2})
+			}
+			if rank, ok := score(fields, terms); ok {
+				recipe := r.recipeView(recipe)
+				results = append(results, models.SearchResult{
+					Type: models.SearchResultRecipe, ID: recipe.ID, Title: recipe.Title,
+					Highlight: highlight(fields, terms), Rank: rank, Recipe: &recipe,
+				})
+			}
+		}
+	}
+	if q.Includes(models.SearchResultBean) && userID != "" {
+		for _, bean := range r.beans {
+			if bean.UserID != userID {
+				continue
+			}
+			fields := []searchField{
+				{bean.Name, 1},
+				{bean.RoasterName + " " + bean.Origin, 0.4},
+				{strings.Join(bean.FlavorNotes, " "), 0.2},
+			}
+			if rank, ok := score(fields, terms); ok {
+				results = append(results, models.SearchResult{
+					Type: models.SearchResultBean, ID: bean.ID, Title: bean.Name,
+					Highlight: highlight(fields, terms), Rank: rank, Bean: &bean,
+				})
+			}
+		}
+	}
+
+	repository.SortResults(results)
+	return q.PageResults(results, offset), nil
+}
+
+// score すべての語がいずれかのフィールドに含まれれば、重み付きの出現数を返す
+func score(fields []searchField, terms []string) (float64, bool) {
+	var rank float64
+	for _, term := range terms {
+		found := false
+		for _, f := range fields {
+			if n := strings.Count(strings.ToLower(f.text), term); n > 0 {
+				rank += f.weight * float64(n)
+				found = true
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return rank, true
+}
+
+// highlight 一致箇所を <mark> で囲んだテキストを返す
+func highlight(fields []searchField, terms []string) string {
+	var texts []string
+	for _, f := range fields {
+		if strings.TrimSpace(f.text) != "" {
+			texts = append(texts, f.text)
+		}
+	}
+	text := strings.Join(texts, " ")
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// 小文字化でバイト長が変わる文字を含む場合は大文字小文字を区別する
+		lower = text
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		matched := ""
+		for _, term := range terms {
+			if strings.HasPrefix(lower[i:], term) && len(term) > len(matched) {
+				matched = term
+			}
+		}
+		if matched != "" {
+			b.WriteString("<mark>" + html.EscapeString(text[i:i+len(matched)]) + "</mark>")
+			i += len(matched)
+			continue
+		}
+		b.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+	return b.String()
+}
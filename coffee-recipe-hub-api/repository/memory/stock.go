@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+func (r *beanRepository) StockHistory(ctx context.Context, beanID string, opts repository.ListOptions) (repository.Page[models.StockMovement], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	movements := []models.StockMovement{}
+	balance := 0
+	for _, m := range r.movements {
+		if m.BeanID != beanID {
+			continue
+		}
+		balance += m.Grams
+		m.BalanceAfter = balance
+		movements = append(movements, m)
+	}
+	if opts.Desc {
+		for i, j := 0, len(movements)-1; i < j; i, j = i+1, j-1 {
+			movements[i], movements[j] = movements[j], movements[i]
+		}
+	}
+	return paginate(movements, opts)
+}
+
+func (r *beanRepository) RecordMovement(ctx context.Context, beanID, userID string, movementType models.StockMovementType, grams int, note string) (*models.StockMovement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bean, ok := r.beans[beanID]
+	if !ok || bean.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	if bean.StockGrams+grams < 0 {
+		return nil, repository.ErrInsufficientStock
+	}
+	m := r.addMovement(models.StockMovement{BeanID: beanID, UserID: userID, Type: movementType, Grams: grams, Note: note})
+	return &m, nil
+}
+
+// addMovement 台帳に1行追加し、豆の在庫（台帳の合計）に反映する
+// 呼び出し側でロックを保持すること
+func (d *db) addMovement(m models.StockMovement) models.StockMovement {
+	m.ID = newID()
+	m.CreatedAt = time.Now()
+	d.movements = append(d.movements, m)
+
+	bean := d.beans[m.BeanID]
+	bean.StockGrams += m.Grams
+	d.beans[m.BeanID] = bean
+
+	m.BalanceAfter = bean.StockGrams
+	return m
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/lib/pq"
+)
+
+const beanColumns = `id, user_id, name, roaster_name, origin, roast_level, process,
+	roast_date, ` + beanStockExpr + `, flavor_notes, rest_days, peak_end_days, stale_days,
+	created_at, updated_at`
+
+type beanRepository struct {
+	db *sql.DB
+}
+
+func scanBean(row scanner) (*models.Bean, error) {
+	var bean models.Bean
+	var roastDate sql.NullTime
+	var restDays, peakEndDays, staleDays sql.NullInt64
+	err := row.Scan(
+		&bean.ID, &bean.UserID, &bean.Name, &bean.RoasterName, &bean.Origin,
+		&bean.RoastLevel, &bean.Process, &roastDate, &bean.StockGrams,
+		pq.Array(&bean.FlavorNotes), &restDays, &peakEndDays, &staleDays,
+		&bean.CreatedAt, &bean.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if roastDate.Valid {
+		bean.RoastDate = roastDate.Time.Format("2006-01-02")
+	}
+	bean.RestDays = intPtr(restDays)
+	bean.PeakEndDays = intPtr(peakEndDays)
+	bean.StaleDays = intPtr(staleDays)
+	return &bean, nil
+}
+
+// intPtr NULL許容の整数列を *int に変換
+func intPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+// parseRoastDate "2006-01-02" 形式の焙煎日をDATE列の値に変換（形式はリクエストの検証で確認済み）
+func parseRoastDate(s string) sql.NullTime {
+	if s == "" {
+		return sql.NullTime{}
+	}
+	t, _ := time.Parse("2006-01-02", s)
+	return sql.NullTime{Time: t, Valid: true}
+}
+
+// beanSorts ソートキーと列の対応
+var beanSorts = map[string]sortColumn{
+	"createdAt":  {"created_at", "timestamptz"},
+	"updatedAt":  {"updated_at", "timestamptz"},
+	"name":       {"name", "text"},
+	"roastDate":  {"COALESCE(roast_date, '0001-01-01')", "date"},
+	"stockGrams": {beanStockExpr, "bigint"},
+}
+
+func (r *beanRepository) ListByUser(ctx context.Context, userID string, f repository.BeanFilter) (repository.Page[models.Bean], error) {
+	q := &listQuery{}
+	q.and("user_id = %s", userID)
+	if f.RoastLevel != "" {
+		q.and("roast_level = %s", f.RoastLevel)
+	}
+	if f.Origin != "" {
+		q.and("origin ILIKE '%%' || %s || '%%'", f.Origin)
+	}
+	if f.From != nil {
+		q.and("roast_date >= %s", *f.From)
+	}
+	if f.To != nil {
+		q.and("roast_date <= %s", *f.To)
+	}
+
+	return queryPage(ctx, r.db, q, beanColumns, "beans", "id", beanSorts[f.Sort], f.ListOptions,
+		scanBean, func(b *models.Bean) string { return b.ID })
+}
+
+func (r *beanRepository) ListAll(ctx context.Context, opts repository.ListOptions) (repository.Page[models.Bean], error) {
+	return queryPage(ctx, r.db, &listQuery{}, beanColumns, "beans", "id", beanSorts["createdAt"], opts,
+		scanBean, func(b *models.Bean) string { return b.ID })
+}
+
+func (r *beanRepository) Get(ctx context.Context, id string) (*models.Bean, error) {
+	bean, err := scanBean(r.db.QueryRowContext(ctx, `
+		SELECT `+beanColumns+`
+		FROM beans WHERE id = $1
+	`, id))
+	return bean, notFound(err)
+}
+
+func (r *beanRepository) Create(ctx context.Context, userID string, req models.CreateBeanRequest) (*models.Bean, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO beans (user_id, name, roaster_name, origin, roast_level, process, roast_date, flavor_notes,
+		                   rest_days, peak_end_days, stale_days)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`, userID, req.Name, req.RoasterName, req.Origin, req.RoastLevel, req.Process,
+		parseRoastDate(req.RoastDate), pq.Array(req.FlavorNotes),
+		req.RestDays, req.PeakEndDays, req.StaleDays).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.StockGrams > 0 {
+		err := insertMovement(ctx, tx, &models.StockMovement{
+			BeanID: id, UserID: userID, Type: models.StockMovementPurchase, Grams: req.StockGrams,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bean, err := scanBean(tx.QueryRowContext(ctx, "SELECT "+beanColumns+" FROM beans WHERE id = $1", id))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return bean, nil
+}
+
+func (r *beanRepository) Update(ctx context.Context, id, userID string, req models.UpdateBeanRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE beans SET name=$1, roaster_name=$2, origin=$3, roast_level=$4,
+		       process=$5, roast_date=$6, flavor_notes=$7, rest_days=$8, peak_end_days=$9,
+		       stale_days=$10, updated_at=NOW()
+		WHERE id=$11 AND user_id=$12
+	`, req.Name, req.RoasterName, req.Origin, req.RoastLevel, req.Process,
+		parseRoastDate(req.RoastDate), pq.Array(req.FlavorNotes), req.RestDays, req.PeakEndDays,
+		req.StaleDays, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := affected(result); err != nil {
+		return err
+	}
+
+	if req.StockGrams != nil {
+		stock, err := lockBeanStock(ctx, tx, id, userID)
+		if err != nil {
+			return err
+		}
+		if delta := *req.StockGrams - stock; delta != 0 {
+			err := insertMovement(ctx, tx, &models.StockMovement{
+				BeanID: id, UserID: userID, Type: models.StockMovementAdjustment, Grams: delta,
+				Note: repository.StockNoteBeanEdited,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *beanRepository) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM beans WHERE id=$1 AND user_id=$2", id, userID)
+	if err != nil {
+		return err
+	}
+	return affected(result)
+}
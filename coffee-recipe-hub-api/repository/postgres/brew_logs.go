@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+const brewLogColumns = `id, user_id, recipe_id, COALESCE(recipe_version, 0), bean_id, brew_date, actual_duration,
+	COALESCE(coffee_grams, 0), COALESCE(water_ml, 0), COALESCE(water_temperature, 0),
+	COALESCE(grind_setting, ''), COALESCE(yield_grams, 0),
+	rating, taste_notes, COALESCE(memo, ''), stock_deducted_grams,
+	shared_at, COALESCE(modifications, ''), created_at`
+
+type brewLogRepository struct {
+	db *sql.DB
+}
+
+func scanBrewLog(row scanner) (*models.BrewLog, error) {
+	var log models.BrewLog
+	var recipeID, beanID sql.NullString
+	var sharedAt sql.NullTime
+	err := row.Scan(
+		&log.ID, &log.UserID, &recipeID, &log.RecipeVersion, &beanID, &log.BrewDate,
+		&log.ActualDuration, &log.CoffeeGrams, &log.WaterMl, &log.WaterTemperature,
+		&log.GrindSetting, &log.YieldGrams, &log.Rating, (*tasteNoteArray)(&log.TasteNotes),
+		&log.Memo, &log.StockDeductedGrams, &sharedAt, &log.Modifications, &log.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	log.RecipeID = recipeID.String
+	log.BeanID = beanID.String
+	if sharedAt.Valid {
+		log.SharedAt = &sharedAt.Time
+	}
+	return &log, nil
+}
+
+// nullString 空文字列をNULLとして扱う
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullVersion 版が未指定（0）ならNULL
+func nullVersion(v int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(v), Valid: v > 0}
+}
+
+// nullYield 未計量（0）の抽出量をNULLとして扱う
+func nullYield(g float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: g, Valid: g > 0}
+}
+
+// brewLogSorts ソートキーと列の対応
+var brewLogSorts = map[string]sortColumn{
+	"brewDate":  {"brew_date", "timestamptz"},
+	"createdAt": {"created_at", "timestamptz"},
+	"rating":    {"COALESCE(rating, 0)", "integer"},
+}
+
+func (r *brewLogRepository) ListByUser(ctx context.Context, userID string, f repository.BrewLogFilter) (repository.Page[models.BrewLog], error) {
+	q := &listQuery{}
+	q.and("user_id = %s", userID)
+	if f.RecipeID != "" {
+		q.and("recipe_id = %s", f.RecipeID)
+	}
+	if f.BeanID != "" {
+		q.and("bean_id = %s", f.BeanID)
+	}
+	if f.MinRating > 0 {
+		q.and("rating >= %s", f.MinRating)
+	}
+	if f.MaxRating > 0 {
+		q.and("rating <= %s", f.MaxRating)
+	}
+	if f.From != nil {
+		q.and("brew_date >= %s", *f.From)
+	}
+	if f.To != nil {
+		q.and("brew_date <= %s", *f.To)
+	}
+
+	return queryPage(ctx, r.db, q, brewLogColumns, "brew_logs", "id", brewLogSorts[f.Sort], f.ListOptions,
+		scanBrewLog, func(l *models.BrewLog) string { return l.ID })
+}
+
+func (r *brewLogRepository) Get(ctx context.Context, id string) (*models.BrewLog, error) {
+	log, err := scanBrewLog(r.db.QueryRowContext(ctx, `
+		SELECT `+brewLogColumns+`
+		FROM brew_logs WHERE id = $1
+	`, id))
+	return log, notFound(err)
+}
+
+func (r *brewLogRepository) Create(ctx context.Context, userID string, req models.CreateBrewLogRequest) (*models.BrewLog, error) {
+	// トランザクション開始
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	deducted, err := stockToDeduct(ctx, tx, userID, req.BeanID, req.CoffeeGrams)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := scanBrewLog(tx.QueryRowContext(ctx, `
+		INSERT INTO brew_logs (user_id, recipe_id, recipe_version, bean_id, actual_duration, coffee_grams, water_ml,
+		                       water_temperature, grind_setting, yield_grams, rating, taste_notes, memo, stock_deducted_grams)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING `+brewLogColumns,
+		userID, nullString(req.RecipeID), nullVersion(req.RecipeVersion), nullString(req.BeanID), req.ActualDuration, req.CoffeeGrams, req.WaterMl,
+		req.WaterTemperature, nullString(req.GrindSetting), nullYield(req.YieldGrams), req.Rating,
+		tasteNoteArray(req.TasteNotes), req.Memo, deducted))
+	if err != nil {
+		return nil, err
+	}
+	if err := recordBrew(ctx, tx, log, deducted); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func (r *brewLogRepository) Update(ctx context.Context, id, userID string, req models.CreateBrewLogRequest) (*models.BrewLog, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	current, err := lockBrewLog(ctx, tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 豆か豆量が変わった場合のみ在庫を付け替える
+	moved := current.BeanID != req.BeanID || current.CoffeeGrams != req.CoffeeGrams
+	deducted := current.StockDeductedGrams
+	if moved {
+		if err := restoreStock(ctx, tx, current, repository.StockNoteBrewLogChanged); err != nil {
+			return nil, err
+		}
+		if deducted, err = stockToDeduct(ctx, tx, userID, req.BeanID, req.CoffeeGrams); err != nil {
+			return nil, err
+		}
+	}
+
+	// レシピが変わったら、別のレシピの「Brewed it!」として残らないよう公開をやめる
+	log, err := scanBrewLog(tx.QueryRowContext(ctx, `
+		UPDATE brew_logs SET recipe_id=$1, recipe_version=$2, bean_id=$3, actual_duration=$4, coffee_grams=$5, water_ml=$6,
+		       water_temperature=$7, grind_setting=$8, yield_grams=$9, rating=$10,
+		       taste_notes=$11, memo=$12, stock_deducted_grams=$13,
+		       shared_at = CASE WHEN recipe_id IS DISTINCT FROM $1 THEN NULL ELSE shared_at END,
+		       modifications = CASE WHEN recipe_id IS DISTINCT FROM $1 THEN NULL ELSE modifications END
+		WHERE id=$14 AND user_id=$15
+		RETURNING `+brewLogColumns,
+		nullString(req.RecipeID), nullVersion(req.RecipeVersion), nullString(req.BeanID), req.ActualDuration, req.CoffeeGrams, req.WaterMl,
+		req.WaterTemperature, nullString(req.GrindSetting), nullYield(req.YieldGrams), req.Rating,
+		tasteNoteArray(req.TasteNotes), req.Memo, deducted, id, userID))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if moved {
+		if err := recordBrew(ctx, tx, log, deducted); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+func (r *brewLogRepository) Delete(ctx context.Context, id, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := lockBrewLog(ctx, tx, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := restoreStock(ctx, tx, current, repository.StockNoteBrewLogDeleted); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM brew_logs WHERE id=$1 AND user_id=$2", id, userID)
+	if err != nil {
+		return err
+	}
+	if err := affected(result); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockBrewLog 在庫を付け替える前にログを行ロックして取得
+func lockBrewLog(ctx context.Context, tx *sql.Tx, id, userID string) (*models.BrewLog, error) {
+	log, err := scanBrewLog(tx.QueryRowContext(ctx, `
+		SELECT `+brewLogColumns+`
+		FROM brew_logs WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`, id, userID))
+	return log, notFound(err)
+}
+
+// stockToDeduct 豆の行をロックし、実際の豆量のうち在庫から差し引ける量を返す
+// 在庫は0未満にならないため、差し引き量は在庫を上限とする
+func stockToDeduct(ctx context.Context, tx *sql.Tx, userID, beanID string, coffeeGrams float64) (int, error) {
+	if beanID == "" || coffeeGrams <= 0 {
+		return 0, nil
+	}
+
+	stock, err := lockBeanStock(ctx, tx, beanID, userID)
+	if err == repository.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	// PostgreSQL の INTEGER への代入と同じく四捨五入
+	return max(min(int(math.Round(coffeeGrams)), stock), 0), nil
+}
+
+// recordBrew 抽出ログで差し引いた量を brew として台帳に記録する
+func recordBrew(ctx context.Context, tx *sql.Tx, log *models.BrewLog, grams int) error {
+	if log.BeanID == "" || grams <= 0 {
+		return nil
+	}
+	return insertMovement(ctx, tx, &models.StockMovement{
+		BeanID: log.BeanID, UserID: log.UserID, Type: models.StockMovementBrew, Grams: -grams, BrewLogID: log.ID,
+	})
+}
+
+// restoreStock 抽出ログで差し引いた在庫を brew の取り消しとして台帳に戻す（豆が削除済みなら何もしない）
+func restoreStock(ctx context.Context, tx *sql.Tx, log *models.BrewLog, note string) error {
+	if log.BeanID == "" || log.StockDeductedGrams <= 0 {
+		return nil
+	}
+	if _, err := lockBeanStock(ctx, tx, log.BeanID, log.UserID); err == repository.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return insertMovement(ctx, tx, &models.StockMovement{
+		BeanID: log.BeanID, UserID: log.UserID, Type: models.StockMovementBrew, Grams: log.StockDeductedGrams,
+		BrewLogID: log.ID, Note: note,
+	})
+}
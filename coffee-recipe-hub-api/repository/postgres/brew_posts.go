@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+const brewPostColumns = `id, recipe_id, COALESCE(recipe_version, 0), user_id, brew_date, actual_duration,
+	COALESCE(coffee_grams, 0), COALESCE(water_ml, 0), COALESCE(water_temperature, 0),
+	COALESCE(grind_setting, ''), COALESCE(yield_grams, 0),
+	rating, taste_notes, COALESCE(memo, ''), COALESCE(modifications, ''), shared_at`
+
+func scanBrewPost(row scanner) (*models.BrewPost, error) {
+	var post models.BrewPost
+	err := row.Scan(
+		&post.ID, &post.RecipeID, &post.RecipeVersion, &post.UserID, &post.BrewDate, &post.ActualDuration,
+		&post.CoffeeGrams, &post.WaterMl, &post.WaterTemperature, &post.GrindSetting, &post.YieldGrams,
+		&post.Rating, (*tasteNoteArray)(&post.TasteNotes), &post.Memo, &post.Modifications, &post.SharedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// brewPostSorts ソートキーと列の対応
+var brewPostSorts = map[string]sortColumn{
+	"sharedAt": {"shared_at", "timestamptz"},
+	"rating":   {"COALESCE(rating, 0)", "integer"},
+}
+
+func (r *brewLogRepository) Share(ctx context.Context, id, userID string, req models.ShareBrewLogRequest) (*models.BrewLog, error) {
+	log, err := scanBrewLog(r.db.QueryRowContext(ctx, `
+		UPDATE brew_logs SET shared_at = COALESCE(shared_at, NOW()), modifications = $1
+		WHERE id = $2 AND user_id = $3
+		RETURNING `+brewLogColumns,
+		nullString(req.Modifications), id, userID))
+	return log, notFound(err)
+}
+
+func (r *brewLogRepository) Unshare(ctx context.Context, id, userID string) (*models.BrewLog, error) {
+	log, err := scanBrewLog(r.db.QueryRowContext(ctx, `
+		UPDATE brew_logs SET shared_at = NULL, modifications = NULL
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+brewLogColumns,
+		id, userID))
+	return log, notFound(err)
+}
+
+func (r *brewLogRepository) ListShared(ctx context.Context, recipeID string, opts repository.ListOptions) (repository.Page[models.BrewPost], error) {
+	q := &listQuery{}
+	q.and("recipe_id = %s", recipeID)
+	q.and("shared_at IS NOT NULL AND hidden_at IS NULL")
+	return queryPage(ctx, r.db, q, brewPostColumns, "brew_logs", "id", brewPostSorts[opts.Sort], opts,
+		scanBrewPost, func(p *models.BrewPost) string { return p.ID })
+}
+
+func (r *brewLogRepository) SharedSummary(ctx context.Context, recipeID string) (*models.BrewSummary, error) {
+	summary := &models.BrewSummary{TasteProfile: []models.TasteAverage{}}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(ROUND(AVG(rating), 2), 0)
+		FROM brew_logs WHERE recipe_id = $1 AND shared_at IS NOT NULL AND hidden_at IS NULL
+	`, recipeID).Scan(&summary.BrewCount, &summary.AverageRating)
+	if err != nil {
+		return nil, err
+	}
+
+	// taste_notes の各要素はJSON文字列
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT note->>'aspect', ROUND(AVG((note->>'score')::numeric), 2), COUNT(*)
+		FROM brew_logs, unnest(taste_notes) AS elem, LATERAL (SELECT elem::jsonb AS note) AS n
+		WHERE recipe_id = $1 AND shared_at IS NOT NULL AND hidden_at IS NULL
+		GROUP BY 1 ORDER BY 1
+	`, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var avg models.TasteAverage
+		if err := rows.Scan(&avg.Aspect, &avg.Average, &avg.Count); err != nil {
+			return nil, err
+		}
+		summary.TasteProfile = append(summary.TasteProfile, avg)
+	}
+	return summary, rows.Err()
+}
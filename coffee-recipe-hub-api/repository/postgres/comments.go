@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/lib/pq"
+)
+
+// commentColumns 投稿者名はプロフィールの表示名。管理者が非表示にしたコメントは削除済みとして扱う
+const commentColumns = `id, recipe_id, parent_id, user_id,
+	COALESCE((SELECT p.display_name FROM profiles p WHERE p.id = recipe_comments.user_id), ''),
+	body, (deleted_at IS NOT NULL OR hidden_at IS NOT NULL), edited_at, created_at`
+
+type commentRepository struct {
+	db *sql.DB
+}
+
+func scanComment(row scanner) (*models.Comment, error) {
+	var c models.Comment
+	var parentID sql.NullString
+	var editedAt sql.NullTime
+	err := row.Scan(
+		&c.ID, &c.RecipeID, &parentID, &c.UserID, &c.AuthorName,
+		&c.Body, &c.Deleted, &editedAt, &c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.ParentID = parentID.String
+	if editedAt.Valid {
+		c.EditedAt = &editedAt.Time
+	}
+	return &c, nil
+}
+
+func (r *commentRepository) List(ctx context.Context, recipeID string, opts repository.ListOptions) (repository.Page[models.Comment], error) {
+	q := &listQuery{}
+	q.and("recipe_id = %s", recipeID)
+	q.and("parent_id IS NULL")
+	// 削除済みでも返信が残っていれば tombstone として残す
+	q.and(`((deleted_at IS NULL AND hidden_at IS NULL) OR EXISTS (
+		SELECT 1 FROM recipe_comments reply
+		WHERE reply.parent_id = recipe_comments.id AND reply.deleted_at IS NULL AND reply.hidden_at IS NULL
+	))`)
+
+	page, err := queryPage(ctx, r.db, q, commentColumns, "recipe_comments", "id", sortColumn{"created_at", "timestamptz"}, opts,
+		scanComment, func(c *models.Comment) string { return c.ID })
+	if err != nil {
+		return page, err
+	}
+	return page, r.attachReplies(ctx, page.Items)
+}
+
+// attachReplies 各コメントに削除・非表示になっていない返信を古い順に設定する
+func (r *commentRepository) attachReplies(ctx context.Context, comments []models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	ids := make([]string, len(comments))
+	index := make(map[string]int, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+		index[c.ID] = i
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+commentColumns+` FROM recipe_comments
+		WHERE parent_id = ANY($1) AND deleted_at IS NULL AND hidden_at IS NULL
+		ORDER BY created_at, id
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		reply, err := scanComment(rows)
+		if err != nil {
+			return err
+		}
+		parent := &comments[index[reply.ParentID]]
+		parent.Replies = append(parent.Replies, *reply)
+	}
+	return rows.Err()
+}
+
+func (r *commentRepository) Get(ctx context.Context, id string) (*models.Comment, error) {
+	c, err := scanComment(r.db.QueryRowContext(ctx, `
+		SELECT `+commentColumns+`
+		FROM recipe_comments WHERE id = $1
+	`, id))
+	return c, notFound(err)
+}
+
+func (r *commentRepository) Create(ctx context.Context, recipeID, userID string, req models.CreateCommentRequest) (*models.Comment, error) {
+	c, err := scanComment(r.db.QueryRowContext(ctx, `
+		INSERT INTO recipe_comments (recipe_id, parent_id, user_id, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+commentColumns,
+		recipeID, nullString(req.ParentID), userID, req.Body))
+	return c, userNotFound(err)
+}
+
+func (r *commentRepository) Update(ctx context.Context, id, userID string, req models.UpdateCommentRequest) (*models.Comment, error) {
+	c, err := scanComment(r.db.QueryRowContext(ctx, `
+		UPDATE recipe_comments
+		SET body = $3, edited_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND hidden_at IS NULL
+		RETURNING `+commentColumns,
+		id, userID, req.Body))
+	return c, notFound(err)
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id, userID string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE recipe_comments c
+		SET deleted_at = NOW(), deleted_by = $2, updated_at = NOW()
+		WHERE c.id = $1 AND c.deleted_at IS NULL
+			AND (c.user_id = $2 OR EXISTS (SELECT 1 FROM recipes WHERE recipes.id = c.recipe_id AND recipes.user_id = $2))
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+	return affected(result)
+}
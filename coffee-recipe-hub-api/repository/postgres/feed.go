@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/lib/pq"
+)
+
+type feedRepository struct {
+	db *sql.DB
+}
+
+func scanFeedItem(row scanner) (*models.FeedItem, error) {
+	var item models.FeedItem
+	if err := row.Scan(&item.Type, &item.ID, &item.UserID, &item.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *feedRepository) Feed(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.FeedItem], error) {
+	q := &listQuery{}
+	followees := `SELECT followee_id FROM user_follows WHERE follower_id = ` + q.arg(userID)
+	// 公開レシピと公開レシピへの Brewed it! を1つの時系列にまとめてからページングする
+	from := `(
+		SELECT 'recipe' AS type, id, user_id, created_at FROM recipes
+		WHERE is_public = true AND hidden_at IS NULL AND user_id IN (` + followees + `)
+		UNION ALL
+		SELECT 'brew', b.id, b.user_id, b.shared_at FROM brew_logs b
+		JOIN recipes r ON r.id = b.recipe_id
+		WHERE b.shared_at IS NOT NULL AND b.hidden_at IS NULL AND r.is_public = true AND r.hidden_at IS NULL
+			AND b.user_id IN (` + followees + `)
+	) AS feed`
+
+	page, err := queryPage(ctx, r.db, q, "type, id, user_id, created_at", from, "id",
+		sortColumn{"created_at", "timestamptz"}, opts, scanFeedItem, func(item *models.FeedItem) string { return item.ID })
+	if err != nil {
+		return page, err
+	}
+	return page, r.hydrate(ctx, page.Items)
+}
+
+// hydrate フィードの各項目にレシピ・Brewed it! の本文を設定する
+func (r *feedRepository) hydrate(ctx context.Context, items []models.FeedItem) error {
+	var recipeIDs, brewIDs []string
+	for _, item := range items {
+		if item.Type == models.FeedItemRecipe {
+			recipeIDs = append(recipeIDs, item.ID)
+		} else {
+			brewIDs = append(brewIDs, item.ID)
+		}
+	}
+
+	recipes := map[string]*models.Recipe{}
+	if len(recipeIDs) > 0 {
+		rows, err := r.db.QueryContext(ctx, `
+			SELECT `+recipeColumns+` FROM recipes WHERE id = ANY($1)
+		`, pq.Array(recipeIDs))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			recipe, err := scanRecipe(rows)
+			if err != nil {
+				return err
+			}
+			recipes[recipe.ID] = recipe
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	brews := map[string]*models.BrewPost{}
+	if len(brewIDs) > 0 {
+		rows, err := r.db.QueryContext(ctx, `
+			SELECT `+brewPostColumns+` FROM brew_logs WHERE id = ANY($1)
+		`, pq.Array(brewIDs))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			post, err := scanBrewPost(rows)
+			if err != nil {
+				return err
+			}
+			brews[post.ID] = post
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for i := range items {
+		items[i].Recipe = recipes[items[i].ID]
+		items[i].Brew = brews[items[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type followRepository struct {
+	db *sql.DB
+}
+
+func scanFollowUser(row scanner) (*models.FollowUser, error) {
+	var f models.FollowUser
+	if err := row.Scan(&f.UserID, &f.FollowedAt); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *followRepository) Follow(ctx context.Context, followerID, followeeID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO user_follows (follower_id, followee_id)
+		VALUES ($1, $2)
+		ON CONFLICT (follower_id, followee_id) DO NOTHING
+	`, followerID, followeeID)
+	if err != nil {
+		// 存在しないユーザーは外部キー違反になる
+		return userNotFound(err)
+	}
+	return nil
+}
+
+func (r *followRepository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM user_follows
+		WHERE follower_id = $1 AND followee_id = $2
+	`, followerID, followeeID)
+	return err
+}
+
+func (r *followRepository) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM user_follows WHERE follower_id = $1 AND followee_id = $2)
+	`, followerID, followeeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *followRepository) ListFollowers(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.FollowUser], error) {
+	q := &listQuery{}
+	q.and("followee_id = %s", userID)
+	return queryPage(ctx, r.db, q, "follower_id, created_at", "user_follows", "follower_id",
+		sortColumn{"created_at", "timestamptz"}, opts, scanFollowUser, func(f *models.FollowUser) string { return f.UserID })
+}
+
+func (r *followRepository) ListFollowing(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.FollowUser], error) {
+	q := &listQuery{}
+	q.and("follower_id = %s", userID)
+	return queryPage(ctx, r.db, q, "followee_id, created_at", "user_follows", "followee_id",
+		sortColumn{"created_at", "timestamptz"}, opts, scanFollowUser, func(f *models.FollowUser) string { return f.UserID })
+}
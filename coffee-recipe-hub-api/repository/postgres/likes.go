@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+type likeRepository struct {
+	db *sql.DB
+}
+
+func (r *likeRepository) Like(ctx context.Context, userID, recipeID string) (*models.LikeStatus, error) {
+	return r.toggle(ctx, userID, recipeID, true, `
+		INSERT INTO recipe_likes (user_id, recipe_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, recipe_id) DO NOTHING
+	`)
+}
+
+func (r *likeRepository) Unlike(ctx context.Context, userID, recipeID string) (*models.LikeStatus, error) {
+	return r.toggle(ctx, userID, recipeID, false, `
+		DELETE FROM recipe_likes
+		WHERE user_id = $1 AND recipe_id = $2
+	`)
+}
+
+// toggle 公開レシピの行をロックしてからいいねを追加・削除し、like_count を同じトランザクションで更新する
+// 同じレシピへの同時のいいねはロックで直列化されるため、件数がずれない
+func (r *likeRepository) toggle(ctx context.Context, userID, recipeID string, liked bool, query string) (*models.LikeStatus, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowContext(ctx, `
+		SELECT id FROM recipes WHERE id = $1 AND is_public = true AND hidden_at IS NULL FOR UPDATE
+	`, recipeID).Scan(&id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, userID, recipeID); err != nil {
+		return nil, err
+	}
+
+	status := &models.LikeStatus{Liked: liked}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE recipes
+		SET like_count = (SELECT COUNT(*) FROM recipe_likes WHERE recipe_id = $1)
+		WHERE id = $1
+		RETURNING like_count
+	`, recipeID).Scan(&status.LikeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (r *likeRepository) IsLiked(ctx context.Context, userID, recipeID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM recipe_likes WHERE user_id = $1 AND recipe_id = $2)
+	`, userID, recipeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *likeRepository) ListLiked(ctx context.Context, userID string, opts repository.ListOptions) (repository.Page[models.Recipe], error) {
+	q := &listQuery{}
+	viewer := q.arg(userID)
+	// 非公開・非表示になったレシピは作者本人のいいねのみ残す
+	from := `(
+		SELECT recipes.*, l.created_at AS liked_at
+		FROM recipe_likes l
+		JOIN recipes ON recipes.id = l.recipe_id
+		WHERE l.user_id = ` + viewer + ` AND ((recipes.is_public = true AND recipes.hidden_at IS NULL) OR recipes.user_id = ` + viewer + `)
+	) AS recipes`
+
+	return queryPage(ctx, r.db, q, recipeColumns+", liked_at", from, "id", sortColumn{"liked_at", "timestamptz"}, opts,
+		scanLikedRecipe, func(r *models.Recipe) string { return r.ID })
+}
+
+// scanLikedRecipe recipeColumns に続けて liked_at を読む
+func scanLikedRecipe(row scanner) (*models.Recipe, error) {
+	var likedAt time.Time
+	recipe, err := scanRecipe(withExtra{row: row, extra: []interface{}{&likedAt}})
+	if err != nil {
+		return nil, err
+	}
+	recipe.LikedByMe = true
+	recipe.LikedAt = &likedAt
+	return recipe, nil
+}
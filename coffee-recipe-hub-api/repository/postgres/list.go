@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+// sortColumn ソートキーに対応するSQL式とカーソル値を戻すときの型
+// NULLを含む列は COALESCE してキーセット比較が成り立つようにする
+type sortColumn struct {
+	expr string
+	cast string
+}
+
+// keysetCursor 最後に返した行のソート値とID
+type keysetCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// listQuery WHERE条件とプレースホルダー引数を積み上げるクエリビルダー
+type listQuery struct {
+	where []string
+	args  []interface{}
+}
+
+// arg 引数を追加してプレースホルダー ($n) を返す
+func (q *listQuery) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// and 条件を追加（cond 内の %s は順に arg のプレースホルダーに置き換える）
+func (q *listQuery) and(cond string, args ...interface{}) {
+	placeholders := make([]interface{}, len(args))
+	for i, a := range args {
+		placeholders[i] = q.arg(a)
+	}
+	q.where = append(q.where, fmt.Sprintf(cond, placeholders...))
+}
+
+// sqlWithKeyset キーセットページング付きのSELECT文を組み立てる
+// 並び順を安定させるため idColumn を第2ソートキーにし、limit+1 件取得して次ページの有無を判定する
+func (q *listQuery) sqlWithKeyset(columns, from, idColumn string, sort sortColumn, opts repository.ListOptions) (string, error) {
+	op, dir := ">", "ASC"
+	if opts.Desc {
+		op, dir = "<", "DESC"
+	}
+
+	if opts.Cursor != "" {
+		var cur keysetCursor
+		if err := repository.DecodeCursor(opts.Cursor, &cur); err != nil {
+			return "", err
+		}
+		if cur.Sort != opts.Sort || cur.Desc != opts.Desc {
+			return "", repository.ErrInvalidCursor
+		}
+		q.where = append(q.where, fmt.Sprintf("(%s, %s) %s (%s::%s, %s::uuid)",
+			sort.expr, idColumn, op, q.arg(cur.Value), sort.cast, q.arg(cur.ID)))
+	}
+
+	query := "SELECT " + columns + ", (" + sort.expr + ")::text FROM " + from
+	if len(q.where) > 0 {
+		query += " WHERE " + strings.Join(q.where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT %d", sort.expr, dir, idColumn, dir, opts.Limit+1)
+	return query, nil
+}
+
+// withExtra スキャン先の末尾に追加の列（ソート値など）を足す
+type withExtra struct {
+	row   scanner
+	extra []interface{}
+}
+
+func (s withExtra) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+// queryPage キーセットページングのクエリを実行して1ページ分を返す
+func queryPage[T any](ctx context.Context, db *sql.DB, q *listQuery, columns, from, idColumn string,
+	sort sortColumn, opts repository.ListOptions, scan func(scanner) (*T, error), id func(*T) string) (repository.Page[T], error) {
+	page := repository.Page[T]{Items: []T{}}
+
+	query, err := q.sqlWithKeyset(columns, from, idColumn, sort, opts)
+	if err != nil {
+		return page, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, q.args...)
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	var lastValue string
+	for rows.Next() {
+		var value string
+		item, err := scan(withExtra{row: rows, extra: []interface{}{&value}})
+		if err != nil {
+			return page, err
+		}
+		if len(page.Items) == opts.Limit {
+			last := &page.Items[len(page.Items)-1]
+			page.NextCursor = repository.EncodeCursor(keysetCursor{
+				Sort: opts.Sort, Desc: opts.Desc, Value: lastValue, ID: id(last),
+			})
+			break
+		}
+		page.Items = append(page.Items, *item)
+		lastValue = value
+	}
+	return page, rows.Err()
+}
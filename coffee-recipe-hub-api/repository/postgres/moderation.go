@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+const reportColumns = `id, target_type, target_id, reporter_id, reason, status, created_at, resolved_at, resolved_by`
+
+// reportTargets 通報できる対象（公開されていて非表示・削除・利用停止になっていない）の条件。$2 が対象のID
+var reportTargets = map[models.ReportTargetType]string{
+	models.ReportTargetRecipe: `EXISTS (
+		SELECT 1 FROM recipes WHERE id = $2 AND is_public = true AND hidden_at IS NULL)`,
+	models.ReportTargetComment: `EXISTS (
+		SELECT 1 FROM recipe_comments c JOIN recipes r ON r.id = c.recipe_id
+		WHERE c.id = $2 AND c.deleted_at IS NULL AND c.hidden_at IS NULL AND r.is_public = true AND r.hidden_at IS NULL)`,
+	models.ReportTargetUser: `EXISTS (
+		SELECT 1 FROM profiles WHERE id = $2 AND banned_at IS NULL)`,
+}
+
+// hideableTables 管理者が個別に非表示にできる対象のテーブル
+var hideableTables = map[models.ReportTargetType]string{
+	models.ReportTargetRecipe:  "recipes",
+	models.ReportTargetComment: "recipe_comments",
+}
+
+// bannedContentTables 利用停止に伴って非表示にするテーブル（いずれも user_id が作者）
+var bannedContentTables = []string{"recipes", "recipe_comments", "brew_logs"}
+
+type moderationRepository struct {
+	db *sql.DB
+}
+
+func scanReport(row scanner) (*models.Report, error) {
+	var report models.Report
+	var resolvedAt sql.NullTime
+	var resolvedBy sql.NullString
+	err := row.Scan(
+		&report.ID, &report.TargetType, &report.TargetID, &report.ReporterID, &report.Reason, &report.Status,
+		&report.CreatedAt, &resolvedAt, &resolvedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		report.ResolvedAt = &resolvedAt.Time
+	}
+	report.ResolvedBy = resolvedBy.String
+	return &report, nil
+}
+
+func (r *moderationRepository) CreateReport(ctx context.Context, reporterID string, req models.CreateReportRequest) (*models.Report, error) {
+	target, ok := reportTargets[req.TargetType]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	// 通報済みなら何も変えずに既存の通報を返す
+	report, err := scanReport(r.db.QueryRowContext(ctx, `
+		INSERT INTO reports (target_type, target_id, reporter_id, reason)
+		SELECT $1::varchar, $2::uuid, $3::uuid, $4
+		WHERE `+target+`
+		ON CONFLICT (target_type, target_id, reporter_id) DO UPDATE SET reason = reports.reason
+		RETURNING `+reportColumns,
+		req.TargetType, req.TargetID, reporterID, req.Reason))
+	return report, userNotFound(err)
+}
+
+// reportSorts ソートキーと列の対応
+var reportSorts = map[string]sortColumn{
+	"createdAt": {"created_at", "timestamptz"},
+}
+
+func (r *moderationRepository) ListReports(ctx context.Context, f repository.ReportFilter) (repository.Page[models.Report], error) {
+	q := &listQuery{}
+	if f.Status != "" {
+		q.and("status = %s", f.Status)
+	}
+	if f.TargetType != "" {
+		q.and("target_type = %s", f.TargetType)
+	}
+	return queryPage(ctx, r.db, q, reportColumns, "reports", "id", reportSorts[f.Sort], f.ListOptions,
+		scanReport, func(r *models.Report) string { return r.ID })
+}
+
+func (r *moderationRepository) UpdateReport(ctx context.Context, id, adminID string, status models.ReportStatus) (*models.Report, error) {
+	report, err := scanReport(r.db.QueryRowContext(ctx, `
+		UPDATE reports SET status = $2, resolved_at = NOW(), resolved_by = $3
+		WHERE id = $1
+		RETURNING `+reportColumns,
+		id, status, adminID))
+	return report, notFound(err)
+}
+
+func (r *moderationRepository) SetHidden(ctx context.Context, targetType models.ReportTargetType, id, adminID string, hidden bool) error {
+	table, ok := hideableTables[targetType]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if !hidden {
+		result, err := r.db.ExecContext(ctx, `
+			UPDATE `+table+` SET hidden_at = NULL, hidden_reason = NULL WHERE id = $1
+		`, id)
+		if err != nil {
+			return err
+		}
+		return affected(result)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 利用停止で非表示になっていたものも個別の非表示に切り替え、利用停止の解除では戻さない
+	result, err := tx.ExecContext(ctx, `
+		UPDATE `+table+` SET hidden_at = COALESCE(hidden_at, NOW()), hidden_reason = 'moderation' WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+	if err := affected(result); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE reports SET status = 'resolved', resolved_at = NOW(), resolved_by = $3
+		WHERE target_type = $1 AND target_id = $2 AND status = 'pending'
+	`, targetType, id, adminID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *moderationRepository) Ban(ctx context.Context, userID, adminID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO profiles (id, banned_at) VALUES ($1, NOW())
+		ON CONFLICT (id) DO UPDATE SET banned_at = COALESCE(profiles.banned_at, NOW()), updated_at = NOW()
+	`, userID)
+	if err != nil {
+		return userNotFound(err)
+	}
+	for _, table := range bannedContentTables {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE `+table+` SET hidden_at = NOW(), hidden_reason = 'ban'
+			WHERE user_id = $1 AND hidden_at IS NULL
+		`, userID)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE reports SET status = 'resolved', resolved_at = NOW(), resolved_by = $2
+		WHERE status = 'pending' AND (
+			(target_type = 'user' AND target_id = $1)
+			OR (target_type = 'recipe' AND target_id IN (SELECT id FROM recipes WHERE user_id = $1))
+			OR (target_type = 'comment' AND target_id IN (SELECT id FROM recipe_comments WHERE user_id = $1))
+		)
+	`, userID, adminID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *moderationRepository) Unban(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE profiles SET banned_at = NULL, updated_at = NOW() WHERE id = $1
+	`, userID)
+	if err != nil {
+		return err
+	}
+	if err := affected(result); err != nil {
+		return err
+	}
+	for _, table := range bannedContentTables {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE `+table+` SET hidden_at = NULL, hidden_reason = NULL
+			WHERE user_id = $1 AND hidden_reason = 'ban'
+		`, userID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *moderationRepository) IsBanned(ctx context.Context, userID string) (bool, error) {
+	var banned bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM profiles WHERE id = $1 AND banned_at IS NOT NULL)
+	`, userID).Scan(&banned)
+	return banned, err
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+const notificationColumns = `id, user_id, type, bean_id, title, body, dedup_key, read_at, created_at`
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+func scanNotification(row scanner) (*models.Notification, error) {
+	var n models.Notification
+	var beanID sql.NullString
+	var readAt sql.NullTime
+	err := row.Scan(
+		&n.ID, &n.UserID, &n.Type, &beanID, &n.Title, &n.Body, &n.DedupKey, &readAt, &n.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n.BeanID = beanID.String
+	if readAt.Valid {
+		n.ReadAt = &readAt.Time
+	}
+	return &n, nil
+}
+
+func (r *notificationRepository) ListByUser(ctx context.Context, userID string, f repository.NotificationFilter) (repository.Page[models.Notification], error) {
+	q := &listQuery{}
+	q.and("user_id = %s", userID)
+	if f.UnreadOnly {
+		q.and("read_at IS NULL")
+	}
+
+	return queryPage(ctx, r.db, q, notificationColumns, "notifications", "id", sortColumn{"created_at", "timestamptz"},
+		f.ListOptions, scanNotification, func(n *models.Notification) string { return n.ID })
+}
+
+func (r *notificationRepository) Create(ctx context.Context, n models.Notification) (*models.Notification, bool, error) {
+	created, err := scanNotification(r.db.QueryRowContext(ctx, `
+		INSERT INTO notifications (user_id, type, bean_id, title, body, dedup_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, dedup_key) DO NOTHING
+		RETURNING `+notificationColumns,
+		n.UserID, n.Type, nullString(n.BeanID), n.Title, n.Body, n.DedupKey))
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id, userID string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+	return affected(result)
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/lib/pq"
+)
+
+// New PostgreSQL をバックエンドとするリポジトリ一式を作成
+func New(db *sql.DB) *repository.Store {
+	return &repository.Store{
+		Beans:         &beanRepository{db: db},
+		Recipes:       &recipeRepository{db: db},
+		BrewLogs:      &brewLogRepository{db: db},
+		Likes:         &likeRepository{db: db},
+		Search:        &searchRepository{db: db},
+		Notifications: &notificationRepository{db: db},
+		Profiles:      &profileRepository{db: db},
+		Follows:       &followRepository{db: db},
+		Feed:          &feedRepository{db: db},
+		Comments:      &commentRepository{db: db},
+		Moderation:    &moderationRepository{db: db},
+	}
+}
+
+// scanner *sql.Row と *sql.Rows の共通部分
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// tasteNoteArray taste_notes (TEXT[]) の各要素をJSONとして読み書きする
+type tasteNoteArray []models.TasteNote
+
+func (a tasteNoteArray) Value() (driver.Value, error) {
+	elems := make([]string, 0, len(a))
+	for _, note := range a {
+		b, err := json.Marshal(note)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, string(b))
+	}
+	return pq.Array(elems).Value()
+}
+
+func (a *tasteNoteArray) Scan(src interface{}) error {
+	var elems []string
+	if err := pq.Array(&elems).Scan(src); err != nil {
+		return err
+	}
+	notes := make(tasteNoteArray, 0, len(elems))
+	for _, elem := range elems {
+		var note models.TasteNote
+		if err := json.Unmarshal([]byte(elem), &note); err != nil {
+			return err
+		}
+		notes = append(notes, note)
+	}
+	*a = notes
+	return nil
+}
+
+// affected 更新件数が0なら ErrNotFound
+func affected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// notFound sql.ErrNoRows を ErrNotFound に変換
+func notFound(err error) error {
+	if err == sql.ErrNoRows {
+		return repository.ErrNotFound
+	}
+	return err
+}
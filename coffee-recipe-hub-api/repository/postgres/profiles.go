@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/lib/pq"
+)
+
+const profileColumns = `id, display_name, COALESCE(avatar_url, ''), banned_at IS NOT NULL, created_at`
+
+type profileRepository struct {
+	db *sql.DB
+}
+
+func scanProfile(row scanner) (*models.User, error) {
+	var u models.User
+	if err := row.Scan(&u.ID, &u.DisplayName, &u.AvatarURL, &u.Banned, &u.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// userNotFound 存在しないユーザーへの外部キー違反を ErrNotFound に変換
+func userNotFound(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return repository.ErrNotFound
+	}
+	return notFound(err)
+}
+
+func (r *profileRepository) Get(ctx context.Context, userID string) (*models.User, error) {
+	u, err := scanProfile(r.db.QueryRowContext(ctx, `
+		SELECT `+profileColumns+`
+		FROM profiles WHERE id = $1
+	`, userID))
+	return u, notFound(err)
+}
+
+func (r *profileRepository) Ensure(ctx context.Context, userID string) (*models.User, error) {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO profiles (id) VALUES ($1)
+		ON CONFLICT (id) DO NOTHING
+	`, userID)
+	if err != nil {
+		return nil, userNotFound(err)
+	}
+	return r.Get(ctx, userID)
+}
+
+func (r *profileRepository) Update(ctx context.Context, userID string, req models.UpdateProfileRequest) (*models.User, error) {
+	u, err := scanProfile(r.db.QueryRowContext(ctx, `
+		INSERT INTO profiles (id, display_name, avatar_url)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE
+		SET display_name = EXCLUDED.display_name, avatar_url = EXCLUDED.avatar_url, updated_at = NOW()
+		RETURNING `+profileColumns,
+		userID, req.DisplayName, nullString(req.AvatarURL)))
+	return u, userNotFound(err)
+}
+
+func (r *profileRepository) Stats(ctx context.Context, userID string) (*models.UserStats, error) {
+	var s models.UserStats
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM recipes WHERE user_id = $1 AND is_public = true AND hidden_at IS NULL),
+			(SELECT COALESCE(SUM(like_count), 0) FROM recipes WHERE user_id = $1 AND is_public = true AND hidden_at IS NULL),
+			(SELECT COALESCE(SUM(fork_count), 0) FROM recipes WHERE user_id = $1 AND is_public = true AND hidden_at IS NULL),
+			(SELECT COUNT(*) FROM brew_logs b JOIN recipes r ON r.id = b.recipe_id
+			 WHERE b.user_id = $1 AND b.shared_at IS NOT NULL AND b.hidden_at IS NULL
+			   AND r.is_public = true AND r.hidden_at IS NULL),
+			(SELECT COUNT(*) FROM user_follows WHERE followee_id = $1),
+			(SELECT COUNT(*) FROM user_follows WHERE follower_id = $1)
+	`, userID).Scan(
+		&s.PublicRecipeCount, &s.LikesReceived, &s.ForksReceived,
+		&s.SharedBrewCount, &s.FollowerCount, &s.FollowingCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/ranking"
+)
+
+// RefreshScores 期間内のイベントから公開レシピのスコアを集計し、recipe_scores を入れ替える
+// 入れ替えは1トランザクションで行うため、一覧は集計中も前回のスコアで並ぶ
+func (r *recipeRepository) RefreshScores(ctx context.Context, cfg ranking.Config, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recipe_scores`); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		WITH events AS (
+			SELECT recipe_id, created_at AS at, $1::float8 AS weight
+			FROM recipe_likes WHERE created_at >= $4
+			UNION ALL
+			SELECT forked_from_recipe_id, created_at, $2::float8
+			FROM recipes WHERE forked_from_recipe_id IS NOT NULL AND created_at >= $4
+			UNION ALL
+			SELECT recipe_id, shared_at, $3::float8
+			FROM brew_logs WHERE shared_at IS NOT NULL AND hidden_at IS NULL AND recipe_id IS NOT NULL AND shared_at >= $4
+		), scores AS (
+			SELECT recipe_id,
+				COALESCE(SUM(weight * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM $5::timestamptz - at), 0) / $8::float8))
+					FILTER (WHERE at >= $6), 0) AS trending,
+				COALESCE(SUM(weight) FILTER (WHERE at >= $7), 0) AS top
+			FROM events
+			GROUP BY recipe_id
+		)
+		INSERT INTO recipe_scores (recipe_id, trending_score, top_score, computed_at)
+		SELECT s.recipe_id, s.trending, s.top, $5
+		FROM scores s
+		JOIN recipes ON recipes.id = s.recipe_id
+		WHERE recipes.is_public = true AND recipes.hidden_at IS NULL AND (s.trending > 0 OR s.top > 0)
+	`, ranking.LikeWeight, ranking.ForkWeight, ranking.BrewWeight,
+		cfg.Since(now), now, cfg.TrendingSince(now), cfg.TopSince(now), cfg.HalfLife.Seconds())
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
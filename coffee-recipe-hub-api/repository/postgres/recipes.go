@@ -0,0 +1,437 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/lib/pq"
+)
+
+// recipeAuthorExpr 作者名はプロフィールの表示名（recipes.author_name は使わない）
+const recipeAuthorExpr = `COALESCE((SELECT p.display_name FROM profiles p WHERE p.id = recipes.user_id), '')`
+
+// recipeOriginalAuthorExpr フォーク元の作者名（プロフィールが削除されていればフォーク時の名前）
+const recipeOriginalAuthorExpr = `COALESCE(
+	(SELECT NULLIF(p.display_name, '') FROM profiles p WHERE p.id = recipes.original_author_id), original_author_name, '')`
+
+const recipeColumns = `id, user_id, title, ` + recipeAuthorExpr + `, equipment, coffee_grams, total_water_ml,
+	water_temperature, grind_size, step_water_mode, steps, COALESCE(tags, '{}'), is_public, like_count, fork_count, current_version,
+	hidden_at IS NOT NULL, forked_from_recipe_id, COALESCE(forked_from_version, 0), original_author_id, ` + recipeOriginalAuthorExpr + `,
+	created_at, updated_at`
+
+const recipeRevisionColumns = `id, recipe_id, version, title, equipment, coffee_grams, total_water_ml,
+	water_temperature, grind_size, step_water_mode, steps, COALESCE(tags, '{}'), restored_from, created_at`
+
+type recipeRepository struct {
+	db *sql.DB
+}
+
+func scanRecipe(row scanner) (*models.Recipe, error) {
+	var recipe models.Recipe
+	var stepsJSON []byte
+	var forkedFrom, originalAuthorID sql.NullString
+	err := row.Scan(
+		&recipe.ID, &recipe.UserID, &recipe.Title, &recipe.AuthorName,
+		&recipe.Equipment, &recipe.CoffeeGrams, &recipe.TotalWaterMl, &recipe.WaterTemperature,
+		&recipe.GrindSize, &recipe.StepWaterMode, &stepsJSON, pq.Array(&recipe.Tags), &recipe.IsPublic,
+		&recipe.LikeCount, &recipe.ForkCount, &recipe.Version, &recipe.Hidden,
+		&forkedFrom, &recipe.ForkedFromVersion, &originalAuthorID, &recipe.OriginalAuthorName,
+		&recipe.CreatedAt, &recipe.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	json.Unmarshal(stepsJSON, &recipe.Steps)
+	recipe.ForkedFromRecipeID = forkedFrom.String
+	recipe.OriginalAuthorID = originalAuthorID.String
+	return &recipe, nil
+}
+
+func scanRecipeRevision(row scanner) (*models.RecipeRevision, error) {
+	var rev models.RecipeRevision
+	var stepsJSON []byte
+	var restoredFrom sql.NullInt64
+	err := row.Scan(
+		&rev.ID, &rev.RecipeID, &rev.Version, &rev.Title,
+		&rev.Equipment, &rev.CoffeeGrams, &rev.TotalWaterMl, &rev.WaterTemperature,
+		&rev.GrindSize, &rev.StepWaterMode, &stepsJSON, pq.Array(&rev.Tags), &restoredFrom, &rev.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	json.Unmarshal(stepsJSON, &rev.Steps)
+	if restoredFrom.Valid {
+		v := int(restoredFrom.Int64)
+		rev.RestoredFrom = &v
+	}
+	return &rev, nil
+}
+
+// recipeSorts ソートキーと列の対応
+var recipeSorts = map[string]sortColumn{
+	"createdAt":   {"created_at", "timestamptz"},
+	"updatedAt":   {"updated_at", "timestamptz"},
+	"title":       {"title", "text"},
+	"likeCount":   {"COALESCE(like_count, 0)", "integer"},
+	"coffeeGrams": {"COALESCE(coffee_grams, 0)", "numeric"},
+	// 集計済みのスコア（recipeListFrom で結合する）
+	"trending": {"trending_score", "double precision"},
+	"top":      {"top_score", "double precision"},
+	"new":      {"created_at", "timestamptz"},
+}
+
+// applyRecipeFilter レシピ一覧共通の絞り込み条件を追加
+func applyRecipeFilter(q *listQuery, f repository.RecipeFilter) {
+	if f.UserID != "" {
+		q.and("user_id = %s", f.UserID)
+	}
+	if f.Equipment != "" {
+		q.and("equipment = %s", f.Equipment)
+	}
+	if f.GrindSize != "" {
+		q.and("grind_size = %s", f.GrindSize)
+	}
+	if f.Tag != "" {
+		q.and("%s = ANY(tags)", f.Tag)
+	}
+	if f.From != nil {
+		q.and("created_at >= %s", *f.From)
+	}
+	if f.To != nil {
+		q.and("created_at <= %s", *f.To)
+	}
+}
+
+// recipeListFrom 閲覧者のいいねと集計済みのスコアを結合したレシピ（列は recipes と同じ名前で参照できる）
+// 未ログインなら viewer は NULL になり、liked_by_me は常に false
+func recipeListFrom(q *listQuery, viewerID string) string {
+	return `(
+		SELECT recipes.*, my_like.user_id IS NOT NULL AS liked_by_me,
+			COALESCE(s.trending_score, 0) AS trending_score, COALESCE(s.top_score, 0) AS top_score
+		FROM recipes
+		LEFT JOIN recipe_likes my_like
+			ON my_like.recipe_id = recipes.id AND my_like.user_id = ` + q.arg(nullString(viewerID)) + `::uuid
+		LEFT JOIN recipe_scores s ON s.recipe_id = recipes.id
+	) AS recipes`
+}
+
+// scanListedRecipe recipeColumns に続けて liked_by_me を読む
+func scanListedRecipe(row scanner) (*models.Recipe, error) {
+	var liked bool
+	recipe, err := scanRecipe(withExtra{row: row, extra: []interface{}{&liked}})
+	if err != nil {
+		return nil, err
+	}
+	recipe.LikedByMe = liked
+	return recipe, nil
+}
+
+func (r *recipeRepository) list(ctx context.Context, q *listQuery, f repository.RecipeFilter) (repository.Page[models.Recipe], error) {
+	from := recipeListFrom(q, f.ViewerID)
+	applyRecipeFilter(q, f)
+	return queryPage(ctx, r.db, q, recipeColumns+", liked_by_me", from, "id", recipeSorts[f.Sort], f.ListOptions,
+		scanListedRecipe, func(r *models.Recipe) string { return r.ID })
+}
+
+func (r *recipeRepository) ListVisible(ctx context.Context, userID string, f repository.RecipeFilter) (repository.Page[models.Recipe], error) {
+	q := &listQuery{}
+	q.and("(user_id = %s OR (is_public = true AND hidden_at IS NULL))", userID)
+	return r.list(ctx, q, f)
+}
+
+func (r *recipeRepository) ListPublic(ctx context.Context, f repository.RecipeFilter) (repository.Page[models.Recipe], error) {
+	q := &listQuery{}
+	q.and("is_public = true AND hidden_at IS NULL")
+	return r.list(ctx, q, f)
+}
+
+func (r *recipeRepository) Get(ctx context.Context, id string) (*models.Recipe, error) {
+	recipe, err := scanRecipe(r.db.QueryRowContext(ctx, `
+		SELECT `+recipeColumns+`
+		FROM recipes WHERE id = $1
+	`, id))
+	return recipe, notFound(err)
+}
+
+func (r *recipeRepository) Create(ctx context.Context, userID string, req models.CreateRecipeRequest) (*models.Recipe, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stepsJSON, _ := json.Marshal(req.Steps)
+	recipe, err := scanRecipe(tx.QueryRowContext(ctx, `
+		INSERT INTO recipes (user_id, title, equipment, coffee_grams, total_water_ml, water_temperature, grind_size,
+		                     step_water_mode, steps, tags, is_public)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING `+recipeColumns,
+		userID, req.Title, req.Equipment, req.CoffeeGrams, req.TotalWaterMl,
+		req.WaterTemperature, req.GrindSize, req.StepWaterMode, stepsJSON, pq.Array(req.Tags), req.IsPublic))
+	if err != nil {
+		return nil, err
+	}
+	if err := snapshotRevision(ctx, tx, recipe.ID, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+func (r *recipeRepository) Update(ctx context.Context, id, userID string, req models.CreateRecipeRequest) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := scanRecipe(tx.QueryRowContext(ctx, `
+		SELECT `+recipeColumns+`
+		FROM recipes WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`, id, userID))
+	if err != nil {
+		return notFound(err)
+	}
+
+	// 公開設定だけの変更では版を増やさない
+	before := brewing.Revision(current)
+	after := requestRevision(req)
+	bump := 0
+	if brewing.Changed(&before, &after) {
+		bump = 1
+	}
+
+	stepsJSON, _ := json.Marshal(req.Steps)
+	result, err := tx.ExecContext(ctx, `
+		UPDATE recipes SET title=$1, equipment=$2, coffee_grams=$3, total_water_ml=$4,
+		       water_temperature=$5, grind_size=$6, step_water_mode=$7, steps=$8, tags=$9, is_public=$10,
+		       current_version=current_version+$11, updated_at=NOW()
+		WHERE id=$12 AND user_id=$13
+	`, req.Title, req.Equipment, req.CoffeeGrams, req.TotalWaterMl,
+		req.WaterTemperature, req.GrindSize, req.StepWaterMode, stepsJSON, pq.Array(req.Tags), req.IsPublic, bump, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := affected(result); err != nil {
+		return err
+	}
+	if bump > 0 {
+		if err := snapshotRevision(ctx, tx, id, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *recipeRepository) Delete(ctx context.Context, id, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var forkedFrom sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM recipes WHERE id=$1 AND user_id=$2
+		RETURNING forked_from_recipe_id
+	`, id, userID).Scan(&forkedFrom)
+	if err != nil {
+		return notFound(err)
+	}
+	// フォークを削除したら元レシピのフォーク数を更新する（元レシピが削除済みなら何もしない）
+	if forkedFrom.Valid {
+		var sourceID string
+		err := tx.QueryRowContext(ctx, `
+			SELECT id FROM recipes WHERE id = $1 FOR UPDATE
+		`, forkedFrom.String).Scan(&sourceID)
+		if err == sql.ErrNoRows {
+			return tx.Commit()
+		}
+		if err != nil {
+			return err
+		}
+		if err := refreshForkCount(ctx, tx, sourceID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *recipeRepository) Fork(ctx context.Context, id, userID string, req models.ForkRecipeRequest) (*models.Recipe, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 元レシピの行をロックして、同じレシピへの同時のフォークで fork_count がずれないようにする
+	var sourceID string
+	err = tx.QueryRowContext(ctx, `
+		SELECT id FROM recipes WHERE id = $1 AND ((is_public = true AND hidden_at IS NULL) OR user_id = $2) FOR UPDATE
+	`, id, userID).Scan(&sourceID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	recipe, err := scanRecipe(tx.QueryRowContext(ctx, `
+		INSERT INTO recipes (user_id, title, equipment, coffee_grams, total_water_ml, water_temperature, grind_size,
+		                     step_water_mode, steps, tags, is_public,
+		                     forked_from_recipe_id, forked_from_version, original_author_id, original_author_name)
+		SELECT $2, COALESCE(NULLIF($3, ''), title), equipment, coffee_grams, total_water_ml, water_temperature, grind_size,
+		       step_water_mode, steps, tags, false,
+		       id, current_version, user_id, (SELECT p.display_name FROM profiles p WHERE p.id = recipes.user_id)
+		FROM recipes WHERE id = $1 AND ((is_public = true AND hidden_at IS NULL) OR user_id = $2)
+		RETURNING `+recipeColumns,
+		id, userID, req.Title))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if err := snapshotRevision(ctx, tx, recipe.ID, nil); err != nil {
+		return nil, err
+	}
+	if err := refreshForkCount(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// maxForkDepth フォークの系統を辿る深さの上限
+const maxForkDepth = 20
+
+func (r *recipeRepository) ListForks(ctx context.Context, id, viewerID string) ([]models.Recipe, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT id, 1 AS depth FROM recipes
+			WHERE forked_from_recipe_id = $1 AND ((is_public = true AND hidden_at IS NULL) OR user_id = $2)
+			UNION ALL
+			SELECT f.id, tree.depth + 1 FROM recipes f
+			JOIN tree ON f.forked_from_recipe_id = tree.id
+			WHERE ((f.is_public = true AND f.hidden_at IS NULL) OR f.user_id = $2) AND tree.depth < $3
+		)
+		SELECT `+recipeColumns+`
+		FROM recipes WHERE id IN (SELECT id FROM tree)
+		ORDER BY created_at, id
+	`, id, nullString(viewerID), maxForkDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	forks := []models.Recipe{}
+	for rows.Next() {
+		recipe, err := scanRecipe(rows)
+		if err != nil {
+			return nil, err
+		}
+		forks = append(forks, *recipe)
+	}
+	return forks, rows.Err()
+}
+
+// refreshForkCount fork_count を更新
+// 呼び出し側で元レシピの行を FOR UPDATE でロックしておくこと（同時のフォーク・削除で件数がずれないように）
+func refreshForkCount(ctx context.Context, tx *sql.Tx, recipeID string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE recipes
+		SET fork_count = (SELECT COUNT(*) FROM recipes WHERE forked_from_recipe_id = $1)
+		WHERE id = $1
+	`, recipeID)
+	return err
+}
+
+// recipeVersionSorts ソートキーと列の対応
+var recipeVersionSorts = map[string]sortColumn{
+	"version": {"version", "integer"},
+}
+
+func (r *recipeRepository) ListVersions(ctx context.Context, recipeID string, opts repository.ListOptions) (repository.Page[models.RecipeRevision], error) {
+	q := &listQuery{}
+	q.and("recipe_id = %s", recipeID)
+	return queryPage(ctx, r.db, q, recipeRevisionColumns, "recipe_revisions", "id", recipeVersionSorts[opts.Sort], opts,
+		scanRecipeRevision, func(rev *models.RecipeRevision) string { return rev.ID })
+}
+
+func (r *recipeRepository) GetVersion(ctx context.Context, recipeID string, version int) (*models.RecipeRevision, error) {
+	rev, err := scanRecipeRevision(r.db.QueryRowContext(ctx, `
+		SELECT `+recipeRevisionColumns+`
+		FROM recipe_revisions WHERE recipe_id = $1 AND version = $2
+	`, recipeID, version))
+	return rev, notFound(err)
+}
+
+func (r *recipeRepository) RestoreVersion(ctx context.Context, id, userID string, version int) (*models.Recipe, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE recipes r SET title=v.title, equipment=v.equipment, coffee_grams=v.coffee_grams,
+		       total_water_ml=v.total_water_ml, water_temperature=v.water_temperature, grind_size=v.grind_size,
+		       step_water_mode=v.step_water_mode, steps=v.steps, tags=v.tags,
+		       current_version=r.current_version+1, updated_at=NOW()
+		FROM recipe_revisions v
+		WHERE r.id=$1 AND r.user_id=$2 AND v.recipe_id=r.id AND v.version=$3
+	`, id, userID, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := affected(result); err != nil {
+		return nil, err
+	}
+	if err := snapshotRevision(ctx, tx, id, &version); err != nil {
+		return nil, err
+	}
+
+	recipe, err := scanRecipe(tx.QueryRowContext(ctx, `
+		SELECT `+recipeColumns+`
+		FROM recipes WHERE id = $1
+	`, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// snapshotRevision レシピの現在の内容を current_version の版として記録する
+func snapshotRevision(ctx context.Context, tx *sql.Tx, recipeID string, restoredFrom *int) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO recipe_revisions (recipe_id, version, title, equipment, coffee_grams, total_water_ml,
+		                              water_temperature, grind_size, step_water_mode, steps, tags, restored_from)
+		SELECT id, current_version, title, equipment, coffee_grams, total_water_ml,
+		       water_temperature, grind_size, step_water_mode, steps, tags, $2
+		FROM recipes WHERE id = $1
+	`, recipeID, restoredFrom)
+	return err
+}
+
+// requestRevision 更新リクエストの内容を版として比較できる形にする
+func requestRevision(req models.CreateRecipeRequest) models.RecipeRevision {
+	return models.RecipeRevision{
+		Title:            req.Title,
+		Equipment:        req.Equipment,
+		CoffeeGrams:      req.CoffeeGrams,
+		TotalWaterMl:     req.TotalWaterMl,
+		WaterTemperature: req.WaterTemperature,
+		GrindSize:        req.GrindSize,
+		StepWaterMode:    req.StepWaterMode,
+		Steps:            req.Steps,
+		Tags:             req.Tags,
+	}
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+	"github.com/lib/pq"
+)
+
+// headlineOptions ts_headline の抜粋設定（一致箇所を <mark> で囲む）
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5, MaxFragments=2"
+
+// escapeHTML テキストの SQL 式を HTML エスケープする式（html.EscapeString と同じ置換）
+// ts_headline は元のテキストをそのまま返すため、<mark> を付ける前にユーザーの入力をエスケープしておく
+// エスケープ後の &amp; などは ts_headline のパーサーで1つのエンティティとして扱われ、途中で切られない
+func escapeHTML(expr string) string {
+	for _, e := range htmlEscapes {
+		expr = "replace(" + expr + ", " + pq.QuoteLiteral(e.from) + ", " + pq.QuoteLiteral(e.to) + ")"
+	}
+	return expr
+}
+
+// htmlEscapes escapeHTML が順に適用する置換（& を最初に置換し、後の置換結果を二重にエスケープしない）
+var htmlEscapes = []struct{ from, to string }{
+	{"&", "&amp;"},
+	{"<", "&lt;"},
+	{">", "&gt;"},
+	{`"`, "&#34;"},
+	{"'", "&#39;"},
+}
+
+type searchRepository struct {
+	db *sql.DB
+}
+
+func (r *searchRepository) Search(ctx context.Context, userID string, q repository.SearchQuery) (repository.Page[models.SearchResult], error) {
+	offset, err := q.Offset()
+	if err != nil {
+		return repository.Page[models.SearchResult]{}, err
+	}
+
+	// 種類ごとに上位 offset+limit+1 件を取り、関連度順にマージしてから切り出す
+	n := offset + q.Limit + 1
+	var results []models.SearchResult
+
+	if q.Includes(models.SearchResultRecipe) {
+		recipes, err := r.searchRecipes(ctx, userID, q.Text, n)
+		if err != nil {
+			return repository.Page[models.SearchResult]{}, err
+		}
+		results = append(results, recipes...)
+	}
+	// 豆は非公開なので本人のものだけ
+	if q.Includes(models.SearchResultBean) && userID != "" {
+		beans, err := r.searchBeans(ctx, userID, q.Text, n)
+		if err != nil {
+			return repository.Page[models.SearchResult]{}, err
+		}
+		results = append(results, beans...)
+	}
+
+	repository.SortResults(results)
+	return q.PageResults(results, offset), nil
+}
+
+// searchRecipes 公開レシピと自分のレシピを検索
+func (r *searchRepository) searchRecipes(ctx context.Context, userID, text string, limit int) ([]models.SearchResult, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+recipeColumns+`, ts_rank(search_vector, query),
+		       ts_headline('simple', `+escapeHTML("concat_ws(' ', title, search_text_array(tags), search_recipe_steps(steps))")+`, query, $4)
+		FROM recipes, websearch_to_tsquery('simple', $1) AS query
+		WHERE search_vector @@ query AND ((is_public = true AND hidden_at IS NULL) OR user_id = $2)
+		ORDER BY ts_rank(search_vector, query) DESC, id
+		LIMIT $3
+	`, text, nullString(userID), limit, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		result := models.SearchResult{Type: models.SearchResultRecipe}
+		recipe, err := scanRecipe(withExtra{row: rows, extra: []interface{}{&result.Rank, &result.Highlight}})
+		if err != nil {
+			return nil, err
+		}
+		result.ID = recipe.ID
+		result.Title = recipe.Title
+		result.Recipe = recipe
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// searchBeans 自分の豆を検索
+func (r *searchRepository) searchBeans(ctx context.Context, userID, text string, limit int) ([]models.SearchResult, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+beanColumns+`, ts_rank(search_vector, query),
+		       ts_headline('simple', `+escapeHTML("concat_ws(' ', name, roaster_name, origin, search_text_array(flavor_notes))")+`, query, $4)
+		FROM beans, websearch_to_tsquery('simple', $1) AS query
+		WHERE search_vector @@ query AND user_id = $2
+		ORDER BY ts_rank(search_vector, query) DESC, id
+		LIMIT $3
+	`, text, userID, limit, headlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		result := models.SearchResult{Type: models.SearchResultBean}
+		bean, err := scanBean(withExtra{row: rows, extra: []interface{}{&result.Rank, &result.Highlight}})
+		if err != nil {
+			return nil, err
+		}
+		result.ID = bean.ID
+		result.Title = bean.Name
+		result.Bean = bean
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"html"
+	"strings"
+	"testing"
+)
+
+func TestEscapeHTMLMatchesHTMLEscapeString(t *testing.T) {
+	for _, s := range []string{
+		`plain text`,
+		`<img src=x onerror="alert(1)">`,
+		`Tom's & Jerry's <b>beans</b>`,
+		`&lt; already escaped &amp;`,
+	} {
+		// SQL の replace と同じく、置換を順に文字列全体へ適用する
+		got := s
+		for _, e := range htmlEscapes {
+			got = strings.ReplaceAll(got, e.from, e.to)
+		}
+		if want := html.EscapeString(s); got != want {
+			t.Errorf("escaping %q = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestEscapeHTMLWrapsExpression(t *testing.T) {
+	got := escapeHTML("title")
+	want := `replace(replace(replace(replace(replace(title, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+	if got != want {
+		t.Errorf("escapeHTML(title) =\n%s\nwant\n%s", got, want)
+	}
+}
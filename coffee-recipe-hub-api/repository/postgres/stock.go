@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/repository"
+)
+
+// beanStockExpr 豆の現在の在庫（台帳の合計）
+const beanStockExpr = `(SELECT COALESCE(SUM(m.grams), 0) FROM bean_stock_movements m WHERE m.bean_id = beans.id)`
+
+const stockMovementColumns = `id, bean_id, user_id, type, grams, balance_after,
+	brew_log_id, COALESCE(note, ''), created_at`
+
+func scanStockMovement(row scanner) (*models.StockMovement, error) {
+	var m models.StockMovement
+	var userID, brewLogID sql.NullString
+	err := row.Scan(
+		&m.ID, &m.BeanID, &userID, &m.Type, &m.Grams, &m.BalanceAfter,
+		&brewLogID, &m.Note, &m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.UserID = userID.String
+	m.BrewLogID = brewLogID.String
+	return &m, nil
+}
+
+func (r *beanRepository) StockHistory(ctx context.Context, beanID string, opts repository.ListOptions) (repository.Page[models.StockMovement], error) {
+	q := &listQuery{}
+	// 反映後の在庫は記録順の累積和で求める
+	from := `(
+		SELECT *, SUM(grams) OVER (ORDER BY created_at, id) AS balance_after
+		FROM bean_stock_movements WHERE bean_id = ` + q.arg(beanID) + `
+	) AS movements`
+
+	return queryPage(ctx, r.db, q, stockMovementColumns, from, "id", sortColumn{"created_at", "timestamptz"}, opts,
+		scanStockMovement, func(m *models.StockMovement) string { return m.ID })
+}
+
+func (r *beanRepository) RecordMovement(ctx context.Context, beanID, userID string, movementType models.StockMovementType, grams int, note string) (*models.StockMovement, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stock, err := lockBeanStock(ctx, tx, beanID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if stock+grams < 0 {
+		return nil, repository.ErrInsufficientStock
+	}
+
+	m := &models.StockMovement{BeanID: beanID, UserID: userID, Type: movementType, Grams: grams, Note: note}
+	if err := insertMovement(ctx, tx, m); err != nil {
+		return nil, err
+	}
+	m.BalanceAfter = stock + grams
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// lockBeanStock 豆の行をロックして現在の在庫を返す
+// 台帳への書き込みは豆ごとにこのロックで直列化する
+func lockBeanStock(ctx context.Context, tx *sql.Tx, beanID, userID string) (int, error) {
+	var stock int
+	err := tx.QueryRowContext(ctx, `
+		SELECT `+beanStockExpr+` FROM beans WHERE id = $1 AND user_id = $2 FOR UPDATE
+	`, beanID, userID).Scan(&stock)
+	return stock, notFound(err)
+}
+
+// insertMovement 台帳に1行追加し、ID と記録日時を m に設定する
+func insertMovement(ctx context.Context, tx *sql.Tx, m *models.StockMovement) error {
+	return tx.QueryRowContext(ctx, `
+		INSERT INTO bean_stock_movements (bean_id, user_id, type, grams, brew_log_id, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, m.BeanID, m.UserID, m.Type, m.Grams, nullString(m.BrewLogID), nullString(m.Note)).Scan(&m.ID, &m.CreatedAt)
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coffee-recipe-hub/api/models"
+	"github.com/coffee-recipe-hub/api/ranking"
+)
+
+// ErrNotFound 対象のレコードが存在しない（または更新条件に一致しない）
+var ErrNotFound = errors.New("not found")
+
+// ErrInsufficientStock 在庫を0未満にする減算
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// 自動で記録する在庫台帳の備考
+const (
+	StockNoteBeanEdited     = "Stock corrected on bean edit"
+	StockNoteBrewLogChanged = "Brew log changed"
+	StockNoteBrewLogDeleted = "Brew log deleted"
+)
+
+// BeanRepository 豆の永続化
+type BeanRepository interface {
+	ListByUser(ctx context.Context, userID string, filter BeanFilter) (Page[models.Bean], error)
+	Get(ctx context.Context, id string) (*models.Bean, error)
+	// Create 豆を作成し、StockGrams があれば purchase として台帳に記録する
+	Create(ctx context.Context, userID string, req models.CreateBeanRequest) (*models.Bean, error)
+	// Update / Delete は id と所有者の両方が一致する行のみ対象とし、なければ ErrNotFound
+	// Update は在庫を直接書き換えず、StockGrams との差分を adjustment として記録する
+	Update(ctx context.Context, id, userID string, req models.UpdateBeanRequest) error
+	Delete(ctx context.Context, id, userID string) error
+	// ListAll 全ユーザーの豆（バックグラウンドジョブ用、作成日順）
+	ListAll(ctx context.Context, opts ListOptions) (Page[models.Bean], error)
+	// StockHistory 豆の在庫台帳（各行に反映後の在庫を付ける）
+	StockHistory(ctx context.Context, beanID string, opts ListOptions) (Page[models.StockMovement], error)
+	// RecordMovement 台帳に1行追加する。在庫が0未満になる場合は ErrInsufficientStock
+	RecordMovement(ctx context.Context, beanID, userID string, movementType models.StockMovementType, grams int, note string) (*models.StockMovement, error)
+}
+
+// RecipeRepository レシピの永続化
+type RecipeRepository interface {
+	// ListVisible 自分のレシピと公開レシピ
+	ListVisible(ctx context.Context, userID string, filter RecipeFilter) (Page[models.Recipe], error)
+	ListPublic(ctx context.Context, filter RecipeFilter) (Page[models.Recipe], error)
+	Get(ctx context.Context, id string) (*models.Recipe, error)
+	// Create レシピと第1版を作成する
+	Create(ctx context.Context, userID string, req models.CreateRecipeRequest) (*models.Recipe, error)
+	// Update 版に含まれる内容が変わった場合は同じトランザクションで新しい版を作成する
+	Update(ctx context.Context, id, userID string, req models.CreateRecipeRequest) error
+	Delete(ctx context.Context, id, userID string) error
+	// ListVersions レシピの版の一覧
+	ListVersions(ctx context.Context, recipeID string, opts ListOptions) (Page[models.RecipeRevision], error)
+	GetVersion(ctx context.Context, recipeID string, version int) (*models.RecipeRevision, error)
+	// RestoreVersion 指定した版の内容を新しい版としてレシピに戻す
+	// id と所有者が一致しないか、版が存在しなければ ErrNotFound
+	RestoreVersion(ctx context.Context, id, userID string, version int) (*models.Recipe, error)
+	// Fork 公開レシピ（または自分のレシピ）の現在の版を userID の非公開レシピとしてコピーし、元レシピの fork_count を更新する
+	// 元レシピが存在しないか閲覧できなければ ErrNotFound
+	Fork(ctx context.Context, id, userID string, req models.ForkRecipeRequest) (*models.Recipe, error)
+	// ListForks id から派生したフォーク（孫以降を含む、作成日順）のうち viewerID が閲覧できるもの
+	// 閲覧できないフォークから先は辿らない
+	ListForks(ctx context.Context, id, viewerID string) ([]models.Recipe, error)
+	// RefreshScores 公開レシピの trending / top スコアを now 時点で集計し直す（一覧の sort=trending|top が使う）
+	RefreshScores(ctx context.Context, cfg ranking.Config, now time.Time) error
+}
+
+// BrewLogRepository 抽出ログの永続化
+type BrewLogRepository interface {
+	ListByUser(ctx context.Context, userID string, filter BrewLogFilter) (Page[models.BrewLog], error)
+	Get(ctx context.Context, id string) (*models.BrewLog, error)
+	// Create ログを作成し、同じトランザクションで豆の在庫から req.CoffeeGrams を差し引く
+	Create(ctx context.Context, userID string, req models.CreateBrewLogRequest) (*models.BrewLog, error)
+	// Update 豆か豆量が変わった場合、以前の差し引き分を戻してから新しい豆から差し引く
+	// レシピが変わった場合は「Brewed it!」の公開をやめる（新しいレシピには改めて公開する）
+	Update(ctx context.Context, id, userID string, req models.CreateBrewLogRequest) (*models.BrewLog, error)
+	// Delete ログを削除し、差し引いた在庫を戻す
+	Delete(ctx context.Context, id, userID string) error
+	// Share ログをレシピへの「Brewed it!」として公開する（公開済みなら変えた点のみ更新し、公開日時は保つ）
+	Share(ctx context.Context, id, userID string, req models.ShareBrewLogRequest) (*models.BrewLog, error)
+	// Unshare 公開をやめる
+	Unshare(ctx context.Context, id, userID string) (*models.BrewLog, error)
+	// ListShared レシピに公開された抽出ログ
+	ListShared(ctx context.Context, recipeID string, opts ListOptions) (Page[models.BrewPost], error)
+	// SharedSummary レシピに公開された抽出ログの評価と味の平均（小数第2位まで）
+	SharedSummary(ctx context.Context, recipeID string) (*models.BrewSummary, error)
+}
+
+// LikeRepository いいねの永続化
+type LikeRepository interface {
+	// Like 既にいいね済みなら何もしない。レシピが存在しないか非公開なら ErrNotFound
+	Like(ctx context.Context, userID, recipeID string) (*models.LikeStatus, error)
+	// Unlike いいねしていなければ何もしない。レシピが存在しないか非公開なら ErrNotFound
+	Unlike(ctx context.Context, userID, recipeID string) (*models.LikeStatus, error)
+	IsLiked(ctx context.Context, userID, recipeID string) (bool, error)
+	// ListLiked userID がいいねしたレシピ（閲覧できるもののみ、いいねした日時順）
+	ListLiked(ctx context.Context, userID string, opts ListOptions) (Page[models.Recipe], error)
+}
+
+// CommentRepository レシピへのコメントの永続化
+type CommentRepository interface {
+	// List トップレベルのコメントを1ページ分、それぞれの返信を含めて返す
+	// 削除済みのコメントは返信が残っている場合のみ含める（本文の除去は呼び出し側で行う）
+	List(ctx context.Context, recipeID string, opts ListOptions) (Page[models.Comment], error)
+	// Get 削除済みのコメントも返す（Deleted で判別する）
+	Get(ctx context.Context, id string) (*models.Comment, error)
+	Create(ctx context.Context, recipeID, userID string, req models.CreateCommentRequest) (*models.Comment, error)
+	// Update 投稿者の削除されていないコメントのみ。それ以外は ErrNotFound
+	Update(ctx context.Context, id, userID string, req models.UpdateCommentRequest) (*models.Comment, error)
+	// Delete 投稿者かレシピの作者による論理削除。それ以外や削除済みなら ErrNotFound
+	Delete(ctx context.Context, id, userID string) error
+}
+
+// ModerationRepository 通報・非表示・利用停止の永続化
+// 非表示のコンテンツと利用停止中のユーザーのコンテンツは、作者本人以外へのすべての読み取りから除外する
+type ModerationRepository interface {
+	// CreateReport 通報をレビュー待ちに追加する。同じユーザーが同じ対象を通報済みならその通報を返す
+	// 対象が存在しないか公開されていなければ ErrNotFound
+	CreateReport(ctx context.Context, reporterID string, req models.CreateReportRequest) (*models.Report, error)
+	ListReports(ctx context.Context, f ReportFilter) (Page[models.Report], error)
+	// UpdateReport 通報の対応状況を更新する
+	UpdateReport(ctx context.Context, id, adminID string, status models.ReportStatus) (*models.Report, error)
+	// SetHidden レシピ・コメントを非表示にする（または戻す）。非表示にすると対象へのレビュー待ちの通報は対応済みになる
+	SetHidden(ctx context.Context, targetType models.ReportTargetType, id, adminID string, hidden bool) error
+	// Ban ユーザーを利用停止にしてレシピ・コメント・Brewed it! を非表示にする
+	// ユーザーとそのレシピ・コメントへのレビュー待ちの通報は対応済みになる
+	Ban(ctx context.Context, userID, adminID string) error
+	// Unban 利用停止を解除し、利用停止に伴って非表示にしたコンテンツを戻す
+	Unban(ctx context.Context, userID string) error
+	IsBanned(ctx context.Context, userID string) (bool, error)
+}
+
+// ProfileRepository プロフィールの永続化
+type ProfileRepository interface {
+	// Get プロフィールがなければ ErrNotFound
+	Get(ctx context.Context, userID string) (*models.User, error)
+	// Ensure プロフィールがなければ空の表示名で作成して返す
+	Ensure(ctx context.Context, userID string) (*models.User, error)
+	// Update プロフィールを更新する（なければ作成する）
+	Update(ctx context.Context, userID string, req models.UpdateProfileRequest) (*models.User, error)
+	// Stats 公開レシピ・Brewed it!・フォローの集計
+	Stats(ctx context.Context, userID string) (*models.UserStats, error)
+}
+
+// FollowRepository フォロー関係の永続化
+type FollowRepository interface {
+	// Follow 既にフォロー済みなら何もしない。followeeID のユーザーが存在しなければ ErrNotFound
+	Follow(ctx context.Context, followerID, followeeID string) error
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+	// ListFollowers userID をフォローしているユーザー（フォローした日時順）
+	ListFollowers(ctx context.Context, userID string, opts ListOptions) (Page[models.FollowUser], error)
+	// ListFollowing userID がフォローしているユーザー（フォローした日時順）
+	ListFollowing(ctx context.Context, userID string, opts ListOptions) (Page[models.FollowUser], error)
+}
+
+// FeedRepository フィードの取得
+type FeedRepository interface {
+	// Feed userID がフォローしているユーザーの公開レシピと、公開レシピへの「Brewed it!」
+	Feed(ctx context.Context, userID string, opts ListOptions) (Page[models.FeedItem], error)
+}
+
+// NotificationRepository 通知の永続化
+type NotificationRepository interface {
+	ListByUser(ctx context.Context, userID string, filter NotificationFilter) (Page[models.Notification], error)
+	// Create 通知を作成する。同じユーザー・DedupKey の通知が既にあれば作成せず false を返す
+	Create(ctx context.Context, n models.Notification) (*models.Notification, bool, error)
+	// MarkRead 既読にする（id と所有者の両方が一致しなければ ErrNotFound）
+	MarkRead(ctx context.Context, id, userID string) error
+}
+
+// SearchRepository レシピと豆の全文検索
+type SearchRepository interface {
+	// Search 公開レシピと userID 自身のレシピ・豆から関連度順に検索する
+	Search(ctx context.Context, userID string, q SearchQuery) (Page[models.SearchResult], error)
+}
+
+// Store ハンドラーに注入するリポジトリ一式
+type Store struct {
+	Beans         BeanRepository
+	Recipes       RecipeRepository
+	BrewLogs      BrewLogRepository
+	Likes         LikeRepository
+	Search        SearchRepository
+	Notifications NotificationRepository
+	Profiles      ProfileRepository
+	Follows       FollowRepository
+	Feed          FeedRepository
+	Comments      CommentRepository
+	Moderation    ModerationRepository
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+// SearchQuery 全文検索の条件
+type SearchQuery struct {
+	Text   string
+	Types  []models.SearchResultType // 空なら全種類
+	Limit  int
+	Cursor string
+}
+
+// Includes 指定の種類を検索対象に含むか
+func (q SearchQuery) Includes(t models.SearchResultType) bool {
+	return len(q.Types) == 0 || slices.Contains(q.Types, t)
+}
+
+// MaxSearchOffset 検索結果を読み進められる件数の上限
+// ページごとに種類ごとの上位 offset+limit+1 件を取得してマージするため、深いページほど重くなる
+const MaxSearchOffset = 500
+
+// searchCursor 関連度順の結果は値が安定しないため、オフセットをカーソルにする
+type searchCursor struct {
+	Text   string `json:"q"`
+	Offset int    `json:"o"`
+}
+
+// Offset カーソルから読み始め位置を取り出す（別の検索語のカーソルは不正）
+func (q SearchQuery) Offset() (int, error) {
+	if q.Cursor == "" {
+		return 0, nil
+	}
+	var cur searchCursor
+	if err := DecodeCursor(q.Cursor, &cur); err != nil {
+		return 0, err
+	}
+	if cur.Text != q.Text || cur.Offset < 0 || cur.Offset > MaxSearchOffset {
+		return 0, ErrInvalidCursor
+	}
+	return cur.Offset, nil
+}
+
+// PageResults 関連度順に並べた結果（offset から limit+1 件以上）を1ページにする
+func (q SearchQuery) PageResults(results []models.SearchResult, offset int) Page[models.SearchResult] {
+	page := Page[models.SearchResult]{Items: []models.SearchResult{}}
+	if offset < len(results) {
+		results = results[offset:]
+	} else {
+		results = nil
+	}
+	if len(results) > q.Limit {
+		page.Items = append(page.Items, results[:q.Limit]...)
+		// 上限を超えるページは返さない
+		if next := offset + q.Limit; next <= MaxSearchOffset {
+			page.NextCursor = EncodeCursor(searchCursor{Text: q.Text, Offset: next})
+		}
+	} else {
+		page.Items = append(page.Items, results...)
+	}
+	return page
+}
+
+// SortResults 関連度の降順、同点は種類・IDで並べる
+func SortResults(results []models.SearchResult) {
+	slices.SortStableFunc(results, func(a, b models.SearchResult) int {
+		switch {
+		case a.Rank > b.Rank:
+			return -1
+		case a.Rank < b.Rank:
+			return 1
+		case a.Type != b.Type:
+			if a.Type < b.Type {
+				return -1
+			}
+			return 1
+		case a.ID < b.ID:
+			return -1
+		case a.ID > b.ID:
+			return 1
+		}
+		return 0
+	})
+}
@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/coffee-recipe-hub/api/brewing"
+	"github.com/coffee-recipe-hub/api/models"
+)
+
+var grindSizes = map[models.GrindSize]bool{
+	models.GrindSizeExtraFine:    true,
+	models.GrindSizeFine:         true,
+	models.GrindSizeMediumFine:   true,
+	models.GrindSizeMedium:       true,
+	models.GrindSizeMediumCoarse: true,
+	models.GrindSizeCoarse:       true,
+}
+
+// Recipe レシピの内容を検証する
+//   - 器具・挽き目は定義済みの値
+//   - 豆量・湯温は器具ごとの妥当な範囲（湯温0は未指定）
+//   - ステップの order は配列の順に 1..n
+//   - ステップの時間は0以上で減らない
+//   - ステップの湯量の累計は総湯量以下（cumulative モードでは0以外の値が減らない）
+func Recipe(req *models.CreateRecipeRequest) Errors {
+	var errs Errors
+
+	if !brewing.KnownEquipment(req.Equipment) {
+		errs.Add("equipment", CodeInvalidValue, "unknown equipment %q", req.Equipment)
+	}
+	if req.GrindSize != "" && !grindSizes[req.GrindSize] {
+		errs.Add("grindSize", CodeInvalidValue, "unknown grind size %q", req.GrindSize)
+	}
+
+	spec := brewing.RangeFor(req.Equipment)
+	if req.CoffeeGrams < spec.MinCoffeeGrams || req.CoffeeGrams > spec.MaxCoffeeGrams {
+		errs.Add("coffeeGrams", CodeOutOfRange, "must be between %g and %g for %s", spec.MinCoffeeGrams, spec.MaxCoffeeGrams, req.Equipment)
+	}
+	if req.TotalWaterMl <= 0 {
+		errs.Add("totalWaterMl", CodeOutOfRange, "must be greater than 0")
+	}
+	if req.WaterTemperature != 0 && (req.WaterTemperature < spec.MinTemperature || req.WaterTemperature > spec.MaxTemperature) {
+		errs.Add("waterTemperature", CodeOutOfRange, "must be between %d and %d for %s", spec.MinTemperature, spec.MaxTemperature, req.Equipment)
+	}
+
+	errs = append(errs, steps(req.Steps, req.StepWaterMode, req.TotalWaterMl)...)
+	return errs
+}
+
+func steps(steps []models.RecipeStep, mode models.StepWaterMode, totalWaterMl int) Errors {
+	var errs Errors
+	prevTime, poured := 0, 0
+	exceeded := false
+	cumulative := mode == models.StepWaterCumulative
+	for i, step := range steps {
+		field := func(name string) string { return fmt.Sprintf("steps[%d].%s", i, name) }
+
+		if step.Order != i+1 {
+			errs.Add(field("order"), CodeInvalidOrder, "must be %d (steps are numbered 1..n in array order)", i+1)
+		}
+
+		if step.TimeSeconds < 0 {
+			errs.Add(field("timeSeconds"), CodeOutOfRange, "must not be negative")
+		} else if step.TimeSeconds < prevTime {
+			errs.Add(field("timeSeconds"), CodeNotMonotonic, "must not be earlier than the previous step (%ds)", prevTime)
+		}
+		prevTime = max(prevTime, step.TimeSeconds)
+
+		if step.WaterMl < 0 {
+			errs.Add(field("waterMl"), CodeOutOfRange, "must not be negative")
+			continue
+		}
+		if cumulative {
+			if step.WaterMl == 0 {
+				continue
+			}
+			if step.WaterMl < poured {
+				errs.Add(field("waterMl"), CodeNotMonotonic, "cumulative target must not be less than the previous step (%d ml)", poured)
+				continue
+			}
+			poured = step.WaterMl
+		} else {
+			poured += step.WaterMl
+		}
+		// 超えた最初のステップだけ報告する
+		if !exceeded && totalWaterMl > 0 && poured > totalWaterMl {
+			errs.Add(field("waterMl"), CodeExceedsTotal, "cumulative water %d ml exceeds totalWaterMl %d ml", poured, totalWaterMl)
+			exceeded = true
+		}
+	}
+	return errs
+}
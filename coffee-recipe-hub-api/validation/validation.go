@@ -0,0 +1,131 @@
+// Package validation リクエストの検証とフィールド単位のエラー
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// エラーコード（クライアントはこれで分岐し、message は表示用）
+const (
+	CodeRequired      = "required"
+	CodeInvalidValue  = "invalid_value"
+	CodeOutOfRange    = "out_of_range"
+	CodeInvalidOrder  = "invalid_order"
+	CodeNotMonotonic  = "not_monotonic"
+	CodeExceedsTotal  = "exceeds_total"
+	CodeInvalidFormat = "invalid_format"
+)
+
+// FieldError 1つのフィールドの検証エラー
+// Field はJSONのパス（例: steps[2].timeSeconds）
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors 検証エラーの一覧
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Field + ": " + fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add エラーを追加
+func (e *Errors) Add(field, code, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// FromBinding ShouldBindJSON のエラーをフィールド単位のエラーに変換
+func FromBinding(err error) Errors {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		errs := make(Errors, 0, len(verrs))
+		for _, fe := range verrs {
+			errs = append(errs, fromFieldError(fe))
+		}
+		return errs
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return Errors{{
+			Field:   typeErr.Field,
+			Code:    CodeInvalidFormat,
+			Message: "must be " + typeName(typeErr.Type.Kind()),
+		}}
+	}
+	return Errors{{Field: "body", Code: CodeInvalidFormat, Message: err.Error()}}
+}
+
+// typeName JSONの型名で表す
+func typeName(k reflect.Kind) string {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// fromFieldError validator のタグをエラーコードに対応させる
+func fromFieldError(fe validator.FieldError) FieldError {
+	field := jsonPath(fe.Namespace())
+	switch fe.Tag() {
+	case "required":
+		return FieldError{Field: field, Code: CodeRequired, Message: "is required"}
+	case "min", "gte":
+		return FieldError{Field: field, Code: CodeOutOfRange, Message: "must be at least " + fe.Param()}
+	case "max", "lte":
+		return FieldError{Field: field, Code: CodeOutOfRange, Message: "must be at most " + fe.Param()}
+	case "gt":
+		return FieldError{Field: field, Code: CodeOutOfRange, Message: "must be greater than " + fe.Param()}
+	case "oneof":
+		return FieldError{Field: field, Code: CodeInvalidValue, Message: "must be one of: " + fe.Param()}
+	case "datetime":
+		return FieldError{Field: field, Code: CodeInvalidFormat, Message: "must be a date in YYYY-MM-DD format"}
+	default:
+		return FieldError{Field: field, Code: CodeInvalidValue, Message: "failed " + fe.Tag() + " validation"}
+	}
+}
+
+// UseJSONNames 検証エラーのフィールド名に構造体のフィールド名ではなくJSONタグの名前を使う
+// FromBinding のパスはこれを登録した validator のエラーを前提にする
+func UseJSONNames(v *validator.Validate) {
+	v.RegisterTagNameFunc(jsonName)
+}
+
+// jsonName JSONタグの名前（タグがなければ構造体のフィールド名）
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// jsonPath "CreateRecipeRequest.steps[0].waterMl" からリクエストの型名を除いて "steps[0].waterMl" にする
+func jsonPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
@@ -12,13 +12,26 @@ const getAuthHeader = async (): Promise<HeadersInit> => {
   return session?.access_token ? { Authorization: `Bearer ${session.access_token}` } : {};
 };
 
+// 一覧APIは { items, nextCursor } 形式のページを返す
+export interface Page<T> {
+  items: T[];
+  nextCursor?: string;
+}
+
+// 公開レシピの並び順（trending: 最近の反応、top: 期間内の合計、new: 新着）
+export type RecipeRanking = 'trending' | 'top' | 'new';
+
+// 一覧画面は1ページ目のみ取得（件数上限は API 側の最大値）
+const LIST_LIMIT = 100;
+
 export const api = {
   // Beans
   getBeans: async () => {
     const headers = await getAuthHeader();
-    const res = await fetch(`${API_URL}/beans`, { headers });
+    const res = await fetch(`${API_URL}/beans?limit=${LIST_LIMIT}`, { headers });
     if (!res.ok) throw new Error('Failed to fetch beans');
-    return res.json();
+    const page: Page<any> = await res.json();
+    return page.items;
   },
 
   createBean: async (bean: any) => {
@@ -53,17 +66,51 @@ export const api = {
     return res.json();
   },
 
+  // 在庫台帳（新しい順）
+  getStockHistory: async (beanId: string, cursor?: string) => {
+    const headers = await getAuthHeader();
+    const query = cursor ? `?cursor=${encodeURIComponent(cursor)}` : '';
+    const res = await fetch(`${API_URL}/beans/${beanId}/stock-history${query}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch stock history');
+    const page: Page<any> = await res.json();
+    return page;
+  },
+
+  // 購入・廃棄・調整の記録（purchase / waste は正の量、adjustment は符号付き）
+  recordStockMovement: async (beanId: string, movement: { type: 'purchase' | 'adjustment' | 'waste'; grams: number; note?: string }) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/beans/${beanId}/stock-movements`, {
+      method: 'POST',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify(movement),
+    });
+    if (!res.ok) throw new Error('Failed to record stock movement');
+    return res.json();
+  },
+
   // Recipes
   getRecipes: async () => {
     const headers = await getAuthHeader();
-    const res = await fetch(`${API_URL}/recipes`, { headers });
+    const res = await fetch(`${API_URL}/recipes?limit=${LIST_LIMIT}`, { headers });
     if (!res.ok) throw new Error('Failed to fetch recipes');
-    return res.json();
+    const page: Page<any> = await res.json();
+    return page.items;
+  },
+
+  getPublicRecipes: async (sort?: RecipeRanking): Promise<any[]> => {
+    const page = await api.getPublicRecipesPage(undefined, sort);
+    return page.items;
   },
 
-  getPublicRecipes: async () => {
+  // コミュニティフィードの無限スクロール用（nextCursor を次の呼び出しに渡す）
+  // sort 省略時は trending（最近の反応が多い順）
+  getPublicRecipesPage: async (cursor?: string, sort?: RecipeRanking): Promise<Page<any>> => {
     const headers = await getAuthHeader();
-    const res = await fetch(`${API_URL}/recipes/public`, { headers });
+    const params = new URLSearchParams();
+    if (sort) params.set('sort', sort);
+    if (cursor) params.set('cursor', cursor);
+    const query = params.toString() ? `?${params.toString()}` : '';
+    const res = await fetch(`${API_URL}/recipes/public${query}`, { headers });
     if (!res.ok) throw new Error('Failed to fetch public recipes');
     return res.json();
   },
@@ -100,6 +147,51 @@ export const api = {
     return res.json();
   },
 
+  // Forks
+  forkRecipe: async (id: string, title?: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${id}/fork`, {
+      method: 'POST',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify(title ? { title } : {}),
+    });
+    if (!res.ok) throw new Error('Failed to fork recipe');
+    return res.json();
+  },
+
+  getRecipeForks: async (id: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${id}/forks`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch recipe forks');
+    return res.json();
+  },
+
+  // Recipe versions
+  getRecipeVersions: async (id: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${id}/versions?limit=${LIST_LIMIT}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch recipe versions');
+    const page: Page<any> = await res.json();
+    return page.items;
+  },
+
+  getRecipeDiff: async (id: string, from?: number, to?: number) => {
+    const headers = await getAuthHeader();
+    const params = new URLSearchParams();
+    if (from) params.set('from', String(from));
+    if (to) params.set('to', String(to));
+    const res = await fetch(`${API_URL}/recipes/${id}/diff?${params}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch recipe diff');
+    return res.json();
+  },
+
+  restoreRecipeVersion: async (id: string, version: number) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${id}/versions/${version}/restore`, { method: 'POST', headers });
+    if (!res.ok) throw new Error('Failed to restore recipe version');
+    return res.json();
+  },
+
   // BrewLogs
   createBrewLog: async (log: any) => {
     const headers = await getAuthHeader();
@@ -114,8 +206,97 @@ export const api = {
 
   getBrewLogs: async () => {
     const headers = await getAuthHeader();
-    const res = await fetch(`${API_URL}/brew-logs`, { headers });
+    const res = await fetch(`${API_URL}/brew-logs?limit=${LIST_LIMIT}`, { headers });
     if (!res.ok) throw new Error('Failed to fetch brew logs');
+    const page: Page<any> = await res.json();
+    return page.items;
+  },
+
+  // Brewed it!
+  shareBrewLog: async (id: string, modifications?: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/brew-logs/${id}/share`, {
+      method: 'POST',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify({ modifications }),
+    });
+    if (!res.ok) throw new Error('Failed to share brew log');
+    return res.json();
+  },
+
+  unshareBrewLog: async (id: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/brew-logs/${id}/share`, { method: 'DELETE', headers });
+    if (!res.ok) throw new Error('Failed to unshare brew log');
+    return res.json();
+  },
+
+  getRecipeBrews: async (recipeId: string, cursor?: string) => {
+    const headers = await getAuthHeader();
+    const query = `?limit=${LIST_LIMIT}${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ''}`;
+    const res = await fetch(`${API_URL}/recipes/${recipeId}/brews${query}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch recipe brews');
+    return res.json();
+  },
+
+  // Comments
+  getRecipeComments: async (recipeId: string, cursor?: string): Promise<Page<any>> => {
+    const headers = await getAuthHeader();
+    const query = `?limit=${LIST_LIMIT}${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ''}`;
+    const res = await fetch(`${API_URL}/recipes/${recipeId}/comments${query}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch comments');
+    return res.json();
+  },
+
+  createRecipeComment: async (recipeId: string, body: string, parentId?: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${recipeId}/comments`, {
+      method: 'POST',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify({ body, parentId }),
+    });
+    if (!res.ok) throw new Error('Failed to post comment');
+    return res.json();
+  },
+
+  updateRecipeComment: async (recipeId: string, commentId: string, body: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${recipeId}/comments/${commentId}`, {
+      method: 'PUT',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify({ body }),
+    });
+    if (!res.ok) throw new Error('Failed to update comment');
+    return res.json();
+  },
+
+  deleteRecipeComment: async (recipeId: string, commentId: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${recipeId}/comments/${commentId}`, { method: 'DELETE', headers });
+    if (!res.ok) throw new Error('Failed to delete comment');
+    return res.json();
+  },
+
+  reportRecipeComment: async (recipeId: string, commentId: string, reason: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/recipes/${recipeId}/comments/${commentId}/report`, {
+      method: 'POST',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify({ reason }),
+    });
+    if (!res.ok) throw new Error('Failed to report comment');
+    return res.json();
+  },
+
+  // 通報（同じ対象を通報済みなら既存の通報が返る）
+  createReport: async (targetType: 'recipe' | 'comment' | 'user', targetId: string, reason: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/reports`, {
+      method: 'POST',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify({ targetType, targetId, reason }),
+    });
+    if (!res.ok) throw new Error('Failed to report');
     return res.json();
   },
 
@@ -146,4 +327,79 @@ export const api = {
     if (!res.ok) throw new Error('Failed to check like status');
     return res.json();
   },
+
+  // Profiles
+  getMe: async () => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/me`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch profile');
+    return res.json();
+  },
+
+  updateMe: async (profile: { displayName: string; avatarUrl?: string }) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/me`, {
+      method: 'PUT',
+      headers: { ...headers, 'Content-Type': 'application/json' },
+      body: JSON.stringify(profile),
+    });
+    if (!res.ok) throw new Error('Failed to update profile');
+    return res.json();
+  },
+
+  getUserProfile: async (userId: string, cursor?: string) => {
+    const headers = await getAuthHeader();
+    const query = `?limit=${LIST_LIMIT}${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ''}`;
+    const res = await fetch(`${API_URL}/users/${userId}${query}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch user profile');
+    return res.json();
+  },
+
+  // いいねしたレシピ（いいねした日時の新しい順）
+  getMyLikesPage: async (cursor?: string): Promise<Page<any>> => {
+    const headers = await getAuthHeader();
+    const query = `?limit=${LIST_LIMIT}${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ''}`;
+    const res = await fetch(`${API_URL}/me/likes${query}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch liked recipes');
+    return res.json();
+  },
+
+  // Follows
+  followUser: async (userId: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/users/${userId}/follow`, { method: 'POST', headers });
+    if (!res.ok) throw new Error('Failed to follow user');
+    return res.json();
+  },
+
+  unfollowUser: async (userId: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/users/${userId}/follow`, { method: 'DELETE', headers });
+    if (!res.ok) throw new Error('Failed to unfollow user');
+    return res.json();
+  },
+
+  getFeedPage: async (cursor?: string): Promise<Page<any>> => {
+    const headers = await getAuthHeader();
+    const query = `?limit=${LIST_LIMIT}${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ''}`;
+    const res = await fetch(`${API_URL}/feed${query}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch feed');
+    return res.json();
+  },
+
+  // Notifications
+  getNotifications: async (unreadOnly = false) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/notifications?limit=${LIST_LIMIT}${unreadOnly ? '&unread=true' : ''}`, { headers });
+    if (!res.ok) throw new Error('Failed to fetch notifications');
+    const page: Page<any> = await res.json();
+    return page.items;
+  },
+
+  markNotificationRead: async (id: string) => {
+    const headers = await getAuthHeader();
+    const res = await fetch(`${API_URL}/notifications/${id}/read`, { method: 'POST', headers });
+    if (!res.ok) throw new Error('Failed to mark notification as read');
+    return res.json();
+  },
 };
@@ -12,14 +12,15 @@ export default function RecipeDetailScreen({ route, navigation }: any) {
   const { addRecipe } = useRecipeStore();
   const { user } = useAuthStore();
   const [importing, setImporting] = useState(false);
-  const [liked, setLiked] = useState(false);
+  const [liked, setLiked] = useState<boolean>(recipe?.likedByMe ?? false);
   const [likeCount, setLikeCount] = useState(recipe?.likeCount || 0);
   const [likeLoading, setLikeLoading] = useState(false);
 
   const isOwnRecipe = user?.id === recipe?.userId;
 
+  // 一覧から渡されたレシピには likedByMe が含まれるため、ない場合のみ問い合わせる
   useEffect(() => {
-    if (recipe?.id && user) {
+    if (recipe?.id && user && recipe.likedByMe === undefined) {
       checkLikeStatus();
     }
   }, [recipe?.id, user]);
@@ -43,15 +44,10 @@ export default function RecipeDetailScreen({ route, navigation }: any) {
 
     setLikeLoading(true);
     try {
-      if (liked) {
-        await api.unlikeRecipe(recipe.id);
-        setLiked(false);
-        setLikeCount((prev) => Math.max(prev - 1, 0));
-      } else {
-        await api.likeRecipe(recipe.id);
-        setLiked(true);
-        setLikeCount((prev) => prev + 1);
-      }
+      // サーバーが返す更新後の状態と件数を反映する
+      const status = liked ? await api.unlikeRecipe(recipe.id) : await api.likeRecipe(recipe.id);
+      setLiked(status.liked);
+      setLikeCount(status.likeCount);
     } catch (e) {
       console.error('Failed to toggle like', e);
     } finally {
@@ -65,7 +61,6 @@ export default function RecipeDetailScreen({ route, navigation }: any) {
     try {
       const importedRecipe = {
         title: `${recipe.title}（インポート）`,
-        authorName: (user as any)?.user_metadata?.display_name || 'Coffee Lover',
         equipment: recipe.equipment,
         coffeeGrams: recipe.coffeeGrams,
         totalWaterMl: recipe.totalWaterMl,
@@ -14,7 +14,7 @@ import {
   Platform,
 } from 'react-native';
 import { Ionicons } from '@expo/vector-icons';
-import { useRecipeStore, useAuthStore } from '../../store';
+import { useRecipeStore } from '../../store';
 import { Equipment, GrindSize, RecipeStep } from '../../types';
 import { api } from '../../lib/api';
 
@@ -37,7 +37,6 @@ const grindOptions: { value: GrindSize; label: string }[] = [
 
 export default function RecipeEditorScreen({ navigation, route }: any) {
   const { addRecipe, updateRecipe, deleteRecipe, loading } = useRecipeStore();
-  const { user } = useAuthStore();
   const editingRecipe = route.params?.recipe;
   const isEditing = !!editingRecipe;
 
@@ -171,8 +170,6 @@ export default function RecipeEditorScreen({ navigation, route }: any) {
 
       const recipeData = {
         title: title.trim(),
-        // ユーザーメタデータから表示名を取得。なければ 'Coffee Lover'
-        authorName: (user as any)?.user_metadata?.display_name || 'Coffee Lover',
         equipment,
         coffeeGrams: parseFloat(coffeeGrams) || 15,
         totalWaterMl: parseInt(totalWaterMl) || 250,
@@ -9,6 +9,9 @@ export type GrindSize = 'EXTRA_FINE' | 'FINE' | 'MEDIUM_FINE' | 'MEDIUM' | 'MEDI
 // 抽出器具
 export type Equipment = 'V60' | 'KALITA_WAVE' | 'CHEMEX' | 'AEROPRESS' | 'FRENCH_PRESS' | 'CLEVER' | 'OTHER';
 
+// 豆の鮮度
+export type FreshnessState = 'resting' | 'peak' | 'fading' | 'stale';
+
 // 豆
 export interface Bean {
   id: string;
@@ -22,19 +25,48 @@ export interface Bean {
   stockGrams: number;
   flavorNotes: string[];
   imageUrl?: string;
+  // 飲み頃の区切りの上書き（焙煎日からの日数）
+  restDays?: number;
+  peakEndDays?: number;
+  staleDays?: number;
+  // 焙煎日から算出（焙煎日が未設定なら省略）
+  daysSinceRoast?: number;
+  freshnessState?: FreshnessState;
   createdAt: string;
   updatedAt: string;
 }
 
+// 在庫台帳の種類
+export type StockMovementType = 'purchase' | 'brew' | 'adjustment' | 'waste';
+
+// 在庫台帳
+export interface StockMovement {
+  id: string;
+  beanId: string;
+  userId: string;
+  type: StockMovementType;
+  grams: number; // 減少は負
+  balanceAfter: number;
+  brewLogId?: string;
+  note?: string;
+  createdAt: string;
+}
+
 // レシピステップ
 export interface RecipeStep {
   order: number;
   label: string;
   timeSeconds: number;
-  waterMl: number;
+  waterMl: number; // Recipe.stepWaterMode に従う
   notes?: string;
+  // API が算出（保存時は不要）
+  cumulativeWaterMl?: number;
+  pourWaterMl?: number;
 }
 
+// ステップの湯量の意味（per_pour: 1投の量 / cumulative: スケールの目標値）
+export type StepWaterMode = 'per_pour' | 'cumulative';
+
 // レシピ
 export interface Recipe {
   id: string;
@@ -46,14 +78,72 @@ export interface Recipe {
   totalWaterMl: number;
   waterTemperature: number;
   grindSize: GrindSize;
+  stepWaterMode?: StepWaterMode;
   steps: RecipeStep[];
   tags?: string[];
   isPublic: boolean;
   likeCount: number;
+  forkCount: number;
+  version: number; // 現在の版
+  hidden?: boolean; // 管理者が非表示にした（作者本人にのみ返る）
+  likedByMe?: boolean; // ログイン中のユーザーがいいね済みか（一覧で返される）
+  likedAt?: string; // いいねした日時（いいねしたレシピ一覧のみ）
+  // フォークしたレシピなら元のレシピと作者
+  forkedFromRecipeId?: string;
+  forkedFromVersion?: number;
+  originalAuthorId?: string;
+  originalAuthorName?: string;
   createdAt: string;
   updatedAt: string;
 }
 
+// レシピのある版の内容
+export interface RecipeRevision {
+  id: string;
+  recipeId: string;
+  version: number;
+  title: string;
+  equipment: Equipment;
+  coffeeGrams: number;
+  totalWaterMl: number;
+  waterTemperature: number;
+  grindSize: GrindSize;
+  stepWaterMode: StepWaterMode;
+  steps: RecipeStep[];
+  tags: string[];
+  restoredFrom?: number;
+  createdAt: string;
+}
+
+// フォークの系統
+export interface RecipeForkNode {
+  id: string;
+  userId: string;
+  authorName?: string;
+  title: string;
+  isPublic: boolean;
+  likeCount: number;
+  forkCount: number;
+  forkedFromVersion?: number;
+  createdAt: string;
+  forks: RecipeForkNode[];
+}
+
+// 2つの版の差分
+export interface RecipeDiff {
+  recipeId: string;
+  fromVersion: number;
+  toVersion: number;
+  fields: { field: string; from: unknown; to: unknown }[];
+  steps: {
+    order: number;
+    change: 'added' | 'removed' | 'modified';
+    from?: RecipeStep;
+    to?: RecipeStep;
+    fields?: string[];
+  }[];
+}
+
 // 味の評価項目
 export interface TasteNote {
   aspect: 'acidity' | 'bitterness' | 'sweetness' | 'body' | 'aftertaste';
@@ -65,20 +155,133 @@ export interface BrewLog {
   id: string;
   userId: string;
   recipeId: string;
+  recipeVersion?: number; // 抽出したときのレシピの版
   beanId: string;
   brewDate: string;
   actualDuration: number;
+  // 実際の抽出条件（省略時はレシピの値）
+  coffeeGrams: number;
+  waterMl: number;
+  waterTemperature: number;
+  grindSetting: string;
+  yieldGrams?: number;
   rating: number;
   tasteNotes: TasteNote[];
   memo?: string;
+  stockDeductedGrams: number;
+  // レシピへの「Brewed it!」として公開した日時（非公開なら undefined）
+  sharedAt?: string;
+  modifications?: string;
   createdAt: string;
 }
 
-// ユーザー
+// レシピに公開された抽出ログ（豆・在庫は含まない）
+export interface BrewPost {
+  id: string;
+  recipeId: string;
+  recipeVersion?: number;
+  userId: string;
+  brewDate: string;
+  actualDuration: number;
+  coffeeGrams: number;
+  waterMl: number;
+  waterTemperature: number;
+  grindSetting: string;
+  yieldGrams?: number;
+  rating: number;
+  tasteNotes: TasteNote[];
+  memo?: string;
+  modifications?: string;
+  sharedAt: string;
+}
+
+// 公開された抽出ログの集計
+export interface BrewSummary {
+  brewCount: number;
+  averageRating: number;
+  tasteProfile: { aspect: TasteNote['aspect']; average: number; count: number }[];
+}
+
+// 通知
+export interface AppNotification {
+  id: string;
+  userId: string;
+  type: 'bean_peak' | 'low_stock';
+  beanId?: string;
+  title: string;
+  body: string;
+  readAt?: string;
+  createdAt: string;
+}
+
+// ユーザー（プロフィール）。email は自分のプロフィールにのみ含まれる
 export interface User {
   id: string;
-  email: string;
+  email?: string;
   displayName: string;
   avatarUrl?: string;
+  banned?: boolean; // 利用停止中（本人にのみ返る）
+  createdAt: string;
+}
+
+// 公開プロフィールの集計
+export interface UserStats {
+  publicRecipeCount: number;
+  likesReceived: number;
+  forksReceived: number;
+  sharedBrewCount: number;
+  followerCount: number;
+  followingCount: number;
+}
+
+// 公開プロフィール
+export interface UserProfile extends User {
+  stats: UserStats;
+  recipes: { items: Recipe[]; nextCursor?: string }; // 公開レシピ（新しい順）
+}
+
+// フォロー・フォロワー一覧の1件
+export interface FollowUser {
+  userId: string;
+  followedAt: string;
+}
+
+// フィードの1件（フォロー中のユーザーの公開レシピ・Brewed it!）
+export interface FeedItem {
+  type: 'recipe' | 'brew';
+  id: string;
+  userId: string;
+  createdAt: string;
+  recipe?: Recipe;
+  brew?: BrewPost;
+}
+
+// レシピへのコメント（返信は1階層まで）。deleted なら本文と投稿者のない tombstone
+export interface Comment {
+  id: string;
+  recipeId: string;
+  parentId?: string;
+  userId?: string;
+  authorName?: string;
+  body: string;
+  deleted: boolean;
+  editedAt?: string;
+  createdAt: string;
+  replies?: Comment[];
+}
+
+// 通報
+export type ReportTargetType = 'recipe' | 'comment' | 'user';
+export type ReportStatus = 'pending' | 'resolved' | 'dismissed';
+
+export interface Report {
+  id: string;
+  targetType: ReportTargetType;
+  targetId: string;
+  reporterId: string;
+  reason: string;
+  status: ReportStatus;
   createdAt: string;
+  resolvedAt?: string;
+  resolvedBy?: string;
 }